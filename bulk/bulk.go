@@ -0,0 +1,99 @@
+// Package bulk reads and writes recipes as JSON Lines or CSV streams, one recipe
+// per line or row, without holding the whole stream in memory.
+package bulk
+
+import (
+	"fmt"
+	"io"
+	"mime"
+	"path/filepath"
+	"strings"
+
+	"github.com/mahesh-yadav/go-recipes-api/models"
+)
+
+const (
+	JSONLines = "jsonl"
+	CSV       = "csv"
+)
+
+var Formats = []string{JSONLines, CSV}
+
+// Row is a recipe read from a stream along with the line it starts on.
+type Row struct {
+	Line   int
+	Recipe models.BulkRecipe
+}
+
+// RowError reports a row that could not be parsed. Reading can continue with the
+// next row.
+type RowError struct {
+	Line int
+	Err  error
+}
+
+func (err *RowError) Error() string {
+	return fmt.Sprintf("line %d: %s", err.Line, err.Err)
+}
+
+func (err *RowError) Unwrap() error {
+	return err.Err
+}
+
+type Reader interface {
+	// Read returns the next row, a *RowError for a malformed row or io.EOF after
+	// the last row. Any other error ends the stream.
+	Read() (Row, error)
+}
+
+type Writer interface {
+	Write(recipe models.ViewRecipe) error
+	// Flush writes any buffered rows to the underlying writer.
+	Flush() error
+}
+
+func NewReader(format string, r io.Reader) (Reader, error) {
+	switch format {
+	case JSONLines:
+		return newJSONLinesReader(r), nil
+	case CSV:
+		return newCSVReader(r)
+	default:
+		return nil, fmt.Errorf("unknown bulk format: %s", format)
+	}
+}
+
+func NewWriter(format string, w io.Writer) (Writer, error) {
+	switch format {
+	case JSONLines:
+		return newJSONLinesWriter(w), nil
+	case CSV:
+		return newCSVWriter(w)
+	default:
+		return nil, fmt.Errorf("unknown bulk format: %s", format)
+	}
+}
+
+func ContentType(format string) string {
+	if format == CSV {
+		return "text/csv; charset=utf-8"
+	}
+	return "application/x-ndjson"
+}
+
+// FormatOfContentType returns the format of a request body, defaulting to JSON Lines.
+func FormatOfContentType(contentType string) string {
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	if mediaType == "text/csv" {
+		return CSV
+	}
+	return JSONLines
+}
+
+// FormatOfPath returns the format of a file from its extension, defaulting to JSON Lines.
+func FormatOfPath(path string) string {
+	if strings.EqualFold(filepath.Ext(path), ".csv") {
+		return CSV
+	}
+	return JSONLines
+}
@@ -0,0 +1,175 @@
+package bulk
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/mahesh-yadav/go-recipes-api/models"
+)
+
+func TestRoundTrip(t *testing.T) {
+	recipe := models.ViewRecipe{
+		Name:        "Lasagna",
+		ExternalID:  "lasagna-001",
+		Status:      models.StatusPublished,
+		Tags:        []string{"pasta", "comfort food"},
+		Ingredients: []string{"12 lasagna noodles", "2 cups ricotta, drained"},
+		Instructions: models.Instructions{
+			{Section: "Sauce", Text: "Warm the sauce."},
+			{Section: "Assembly", Text: "Layer noodles, ricotta and sauce."},
+			{Section: "Assembly", Text: "Bake for 45 minutes."},
+		},
+		Servings:    8,
+		PrepTime:    "PT20M",
+		Course:      "main",
+		Equipment:   []string{"baking dish"},
+		Visibility:  "public",
+		Attribution: &models.Attribution{Source: "Weeknight Kitchen", URL: "https://weeknight.example/lasagna"},
+	}
+	want := models.BulkRecipe{
+		AddUpdateRecipe: models.AddUpdateRecipe{
+			Name:         recipe.Name,
+			Tags:         recipe.Tags,
+			Ingredients:  recipe.Ingredients,
+			Instructions: recipe.Instructions,
+			Servings:     recipe.Servings,
+			PrepTime:     recipe.PrepTime,
+			Course:       recipe.Course,
+			Equipment:    recipe.Equipment,
+			Visibility:   recipe.Visibility,
+			Attribution:  recipe.Attribution,
+		},
+		ExternalID: recipe.ExternalID,
+		Status:     recipe.Status,
+	}
+
+	for _, format := range Formats {
+		t.Run(format, func(t *testing.T) {
+			var buffer bytes.Buffer
+			writer, err := NewWriter(format, &buffer)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := writer.Write(recipe); err != nil {
+				t.Fatal(err)
+			}
+			if err := writer.Flush(); err != nil {
+				t.Fatal(err)
+			}
+
+			reader, err := NewReader(format, &buffer)
+			if err != nil {
+				t.Fatal(err)
+			}
+			row, err := reader.Read()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(row.Recipe, want) {
+				t.Errorf("read %+v\nwant %+v", row.Recipe, want)
+			}
+			if _, err := reader.Read(); err != io.EOF {
+				t.Errorf("second read error = %v, want io.EOF", err)
+			}
+		})
+	}
+}
+
+func TestCSVHeader(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		err   string
+	}{
+		{name: "empty", input: "", err: "missing CSV header"},
+		{name: "unknown column", input: "name,rating\n", err: `unknown CSV column: "rating"`},
+		{name: "duplicate column", input: "name,Name\n", err: `duplicate CSV column: "name"`},
+		{name: "missing name", input: "external_id,tags\n", err: `missing CSV column: "name"`},
+		{name: "byte order mark", input: "\xef\xbb\xbfName , Tags\n"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := NewReader(CSV, strings.NewReader(test.input))
+			if test.err == "" && err != nil {
+				t.Fatalf("error = %v, want none", err)
+			}
+			if test.err != "" && (err == nil || err.Error() != test.err) {
+				t.Fatalf("error = %v, want %s", err, test.err)
+			}
+		})
+	}
+}
+
+func TestCSVRows(t *testing.T) {
+	input := "name,tags,servings,instructions\n" +
+		"Soup,\"soup, winter\nvegan\",4,\"Chop.\n\n# Cook\nSimmer.\"\n" +
+		"Stew,,many,Simmer.\n" +
+		"Salad,,,\n"
+	reader, err := NewReader(CSV, strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	row, err := reader.Read()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if row.Line != 2 {
+		t.Errorf("line = %d, want 2", row.Line)
+	}
+	if want := []string{"soup", "winter", "vegan"}; !reflect.DeepEqual(row.Recipe.Tags, want) {
+		t.Errorf("tags = %q, want %q", row.Recipe.Tags, want)
+	}
+	if row.Recipe.Servings != 4 {
+		t.Errorf("servings = %d, want 4", row.Recipe.Servings)
+	}
+	want := models.Instructions{{Text: "Chop."}, {Section: "Cook", Text: "Simmer."}}
+	if !reflect.DeepEqual(row.Recipe.Instructions, want) {
+		t.Errorf("instructions = %+v, want %+v", row.Recipe.Instructions, want)
+	}
+
+	_, err = reader.Read()
+	var rowError *RowError
+	if !errors.As(err, &rowError) || rowError.Line != 7 {
+		t.Fatalf("error = %v, want a row error on line 7", err)
+	}
+
+	row, err = reader.Read()
+	if err != nil || row.Recipe.Name != "Salad" || row.Line != 8 {
+		t.Fatalf("read %+v, %v, want Salad on line 8", row, err)
+	}
+}
+
+func TestJSONLinesReader(t *testing.T) {
+	input := "{\"name\": \"Soup\", \"external_id\": \"soup-1\", \"id\": \"65f1c0e2a1b2c3d4e5f60718\"}\n" +
+		"\n" +
+		"{\"name\": \"Stew\"\n" +
+		"{\"name\": \"Salad\"}"
+	reader, err := NewReader(JSONLines, strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	row, err := reader.Read()
+	if err != nil || row.Line != 1 || row.Recipe.Name != "Soup" || row.Recipe.ExternalID != "soup-1" {
+		t.Fatalf("read %+v, %v, want soup-1 on line 1", row, err)
+	}
+
+	_, err = reader.Read()
+	var rowError *RowError
+	if !errors.As(err, &rowError) || rowError.Line != 3 {
+		t.Fatalf("error = %v, want a row error on line 3", err)
+	}
+
+	row, err = reader.Read()
+	if err != nil || row.Line != 4 || row.Recipe.Name != "Salad" {
+		t.Fatalf("read %+v, %v, want Salad on line 4", row, err)
+	}
+	if _, err := reader.Read(); err != io.EOF {
+		t.Errorf("error = %v, want io.EOF", err)
+	}
+}
@@ -0,0 +1,226 @@
+package bulk
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/mahesh-yadav/go-recipes-api/models"
+)
+
+// Columns are the CSV columns in the order they are exported. Imports need a
+// header row naming at least the name column, in any order.
+//
+// Cells holding lists have one item per line; tags and equipment may also be
+// separated by commas. In instructions, a line starting with "#" names the section
+// of the steps that follow.
+var Columns = []string{
+	"external_id", "name", "status", "tags", "ingredients", "instructions",
+	"servings", "prep_time", "cook_time", "total_time", "difficulty", "cuisine",
+	"course", "equipment", "yield", "visibility", "default_locale",
+	"source", "source_author", "source_url",
+}
+
+const sectionPrefix = "#"
+
+type csvReader struct {
+	reader  *csv.Reader
+	columns []string
+}
+
+func newCSVReader(r io.Reader) (*csvReader, error) {
+	reader := csv.NewReader(r)
+	reader.ReuseRecord = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, errors.New("missing CSV header")
+	} else if err != nil {
+		return nil, err
+	}
+
+	columns := make([]string, len(header))
+	for i, column := range header {
+		column = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(column, "\uFEFF")))
+		if !slices.Contains(Columns, column) {
+			return nil, fmt.Errorf("unknown CSV column: %q", column)
+		}
+		if slices.Contains(columns[:i], column) {
+			return nil, fmt.Errorf("duplicate CSV column: %q", column)
+		}
+		columns[i] = column
+	}
+	if !slices.Contains(columns, "name") {
+		return nil, errors.New("missing CSV column: \"name\"")
+	}
+	return &csvReader{reader: reader, columns: columns}, nil
+}
+
+func (reader *csvReader) Read() (Row, error) {
+	record, err := reader.reader.Read()
+	var parseError *csv.ParseError
+	if errors.As(err, &parseError) {
+		return Row{Line: parseError.StartLine}, &RowError{Line: parseError.StartLine, Err: parseError.Err}
+	} else if err != nil {
+		return Row{}, err
+	}
+	line, _ := reader.reader.FieldPos(0)
+
+	row := Row{Line: line}
+	recipe := &row.Recipe
+	for i, column := range reader.columns {
+		value := strings.TrimSpace(record[i])
+		switch column {
+		case "external_id":
+			recipe.ExternalID = value
+		case "name":
+			recipe.Name = value
+		case "status":
+			recipe.Status = value
+		case "tags":
+			recipe.Tags = splitItems(value, true)
+		case "ingredients":
+			recipe.Ingredients = splitItems(value, false)
+		case "instructions":
+			recipe.Instructions = parseInstructions(value)
+		case "servings":
+			if value != "" {
+				servings, err := strconv.Atoi(value)
+				if err != nil {
+					return row, &RowError{Line: line, Err: fmt.Errorf("servings: invalid number %q", value)}
+				}
+				recipe.Servings = servings
+			}
+		case "prep_time":
+			recipe.PrepTime = value
+		case "cook_time":
+			recipe.CookTime = value
+		case "total_time":
+			recipe.TotalTime = value
+		case "difficulty":
+			recipe.Difficulty = value
+		case "cuisine":
+			recipe.Cuisine = value
+		case "course":
+			recipe.Course = value
+		case "equipment":
+			recipe.Equipment = splitItems(value, true)
+		case "yield":
+			recipe.Yield = value
+		case "visibility":
+			recipe.Visibility = value
+		case "default_locale":
+			recipe.DefaultLocale = value
+		case "source", "source_author", "source_url":
+			if value == "" {
+				continue
+			}
+			if recipe.Attribution == nil {
+				recipe.Attribution = &models.Attribution{}
+			}
+			switch column {
+			case "source":
+				recipe.Attribution.Source = value
+			case "source_author":
+				recipe.Attribution.Author = value
+			default:
+				recipe.Attribution.URL = value
+			}
+		}
+	}
+	return row, nil
+}
+
+func splitItems(value string, commas bool) []string {
+	separators := "\n"
+	if commas {
+		separators += ","
+	}
+	items := make([]string, 0)
+	for _, item := range strings.FieldsFunc(value, func(r rune) bool { return strings.ContainsRune(separators, r) }) {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+func parseInstructions(value string) models.Instructions {
+	instructions := make(models.Instructions, 0)
+	section := ""
+	for _, line := range splitItems(value, false) {
+		if strings.HasPrefix(line, sectionPrefix) {
+			section = strings.TrimSpace(strings.TrimPrefix(line, sectionPrefix))
+			continue
+		}
+		instructions = append(instructions, models.Step{Section: section, Text: line})
+	}
+	return instructions
+}
+
+type csvWriter struct {
+	writer *csv.Writer
+}
+
+func newCSVWriter(w io.Writer) (*csvWriter, error) {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(Columns); err != nil {
+		return nil, err
+	}
+	return &csvWriter{writer: writer}, nil
+}
+
+func (writer *csvWriter) Write(recipe models.ViewRecipe) error {
+	attribution := recipe.Attribution
+	if attribution == nil {
+		attribution = &models.Attribution{}
+	}
+	servings := ""
+	if recipe.Servings > 0 {
+		servings = strconv.Itoa(recipe.Servings)
+	}
+	return writer.writer.Write([]string{
+		recipe.ExternalID,
+		recipe.Name,
+		recipe.Status,
+		strings.Join(recipe.Tags, "\n"),
+		strings.Join(recipe.Ingredients, "\n"),
+		formatInstructions(recipe.Instructions),
+		servings,
+		recipe.PrepTime,
+		recipe.CookTime,
+		recipe.TotalTime,
+		recipe.Difficulty,
+		recipe.Cuisine,
+		recipe.Course,
+		strings.Join(recipe.Equipment, "\n"),
+		recipe.Yield,
+		recipe.Visibility,
+		recipe.DefaultLocale,
+		attribution.Source,
+		attribution.Author,
+		attribution.URL,
+	})
+}
+
+func formatInstructions(instructions models.Instructions) string {
+	lines := make([]string, 0, len(instructions))
+	section := ""
+	for _, step := range instructions {
+		if step.Section != section && step.Section != "" {
+			lines = append(lines, sectionPrefix+" "+step.Section)
+		}
+		section = step.Section
+		lines = append(lines, step.Text)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (writer *csvWriter) Flush() error {
+	writer.writer.Flush()
+	return writer.writer.Error()
+}
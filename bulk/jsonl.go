@@ -0,0 +1,61 @@
+package bulk
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+
+	"github.com/mahesh-yadav/go-recipes-api/models"
+)
+
+type jsonLinesReader struct {
+	reader *bufio.Reader
+	line   int
+}
+
+func newJSONLinesReader(r io.Reader) *jsonLinesReader {
+	return &jsonLinesReader{reader: bufio.NewReader(r)}
+}
+
+// Read skips blank lines. Fields other than those of models.BulkRecipe are
+// ignored, so exported recipes can be imported again.
+func (reader *jsonLinesReader) Read() (Row, error) {
+	for {
+		data, err := reader.reader.ReadBytes('\n')
+		if err != nil && (err != io.EOF || len(data) == 0) {
+			return Row{}, err
+		}
+		reader.line++
+
+		data = bytes.TrimSpace(data)
+		if len(data) == 0 {
+			continue
+		}
+		row := Row{Line: reader.line}
+		if err := json.Unmarshal(data, &row.Recipe); err != nil {
+			return row, &RowError{Line: reader.line, Err: err}
+		}
+		return row, nil
+	}
+}
+
+type jsonLinesWriter struct {
+	writer  *bufio.Writer
+	encoder *json.Encoder
+}
+
+func newJSONLinesWriter(w io.Writer) *jsonLinesWriter {
+	writer := bufio.NewWriter(w)
+	encoder := json.NewEncoder(writer)
+	encoder.SetEscapeHTML(false)
+	return &jsonLinesWriter{writer: writer, encoder: encoder}
+}
+
+func (writer *jsonLinesWriter) Write(recipe models.ViewRecipe) error {
+	return writer.encoder.Encode(recipe)
+}
+
+func (writer *jsonLinesWriter) Flush() error {
+	return writer.writer.Flush()
+}
@@ -1,6 +1,7 @@
 package config
 
 import (
+	"errors"
 	"log"
 
 	"github.com/caarlos0/env/v11"
@@ -12,23 +13,44 @@ import (
 var config *Config
 
 type Config struct {
-	LogLevel                      string `env:"LOG_LEVEL" envDefault:"info"`
-	LogFile                       string `env:"LOG_FILE" envDefault:"app.log"`
-	LogMaxAge                     int    `env:"LOG_MAX_AGE" envDefault:"7"`
-	LogMaxSizeInMB                int    `env:"LOG_MAX_SIZE_IN_MB" envDefault:"10"`
-	LogCompress                   bool   `env:"LOG_COMPRESS" envDefault:"false"`
-	MongoUri                      string `env:"MONGO_URI,notEmpty"`
-	MongoDBName                   string `env:"MONGO_DB_NAME,notEmpty"`
-	MongoServerSelectionTimeoutMS int    `env:"MONGO_SERVER_SELECTION_TIMEOUT_MS" envDefault:"5000"`
-	Port                          string `env:"PORT" envDefault:"8080"`
-	GinMode                       string `env:"GIN_MODE" envDefault:"debug"`
-	InitializeDB                  bool   `env:"INITIALIZE_DB" envDefault:"false"`
-	RedisUri                      string `env:"REDIS_URI,notEmpty"`
-	RedisPassword                 string `env:"REDIS_PASSWORD"`
-	RedisDB                       int    `env:"REDIS_DB" envDefault:"0"`
-	EnableRedisCache              bool   `env:"ENABLE_REDIS_CACHE" envDefault:"false"`
-	JWTSecret                     string `env:"JWT_SECRET,notEmpty"`
-	JWTExpirationTimeSeconds      int    `env:"JWT_EXPIRATION_TIME_SECONDS" envDefault:"600"`
+	LogLevel                        string   `env:"LOG_LEVEL" envDefault:"info"`
+	LogFile                         string   `env:"LOG_FILE" envDefault:"app.log"`
+	LogMaxAge                       int      `env:"LOG_MAX_AGE" envDefault:"7"`
+	LogMaxSizeInMB                  int      `env:"LOG_MAX_SIZE_IN_MB" envDefault:"10"`
+	LogCompress                     bool     `env:"LOG_COMPRESS" envDefault:"false"`
+	MongoUri                        string   `env:"MONGO_URI,notEmpty"`
+	MongoDBName                     string   `env:"MONGO_DB_NAME,notEmpty"`
+	MongoServerSelectionTimeoutMS   int      `env:"MONGO_SERVER_SELECTION_TIMEOUT_MS" envDefault:"5000"`
+	Port                            string   `env:"PORT" envDefault:"8080"`
+	GinMode                         string   `env:"GIN_MODE" envDefault:"debug"`
+	InitializeDB                    bool     `env:"INITIALIZE_DB" envDefault:"false"`
+	RedisUri                        string   `env:"REDIS_URI,notEmpty"`
+	RedisPassword                   string   `env:"REDIS_PASSWORD"`
+	RedisDB                         int      `env:"REDIS_DB" envDefault:"0"`
+	EnableRedisCache                bool     `env:"ENABLE_REDIS_CACHE" envDefault:"false"`
+	JWTSecret                       string   `env:"JWT_SECRET,notEmpty"`
+	JWTExpirationTimeSeconds        int      `env:"JWT_EXPIRATION_TIME_SECONDS" envDefault:"600"`
+	BlobStore                       string   `env:"BLOB_STORE" envDefault:"local"`
+	BlobLocalDir                    string   `env:"BLOB_LOCAL_DIR" envDefault:"uploads"`
+	BlobBaseURL                     string   `env:"BLOB_BASE_URL"`
+	S3Endpoint                      string   `env:"S3_ENDPOINT" envDefault:"http://localhost:9000"`
+	S3Region                        string   `env:"S3_REGION" envDefault:"us-east-1"`
+	S3Bucket                        string   `env:"S3_BUCKET" envDefault:"recipes"`
+	S3AccessKey                     string   `env:"S3_ACCESS_KEY"`
+	S3SecretKey                     string   `env:"S3_SECRET_KEY"`
+	ImageMaxSizeMB                  int      `env:"IMAGE_MAX_SIZE_MB" envDefault:"10"`
+	PublishSchedulerIntervalSeconds int      `env:"PUBLISH_SCHEDULER_INTERVAL_SECONDS" envDefault:"60"`
+	AdminUsernames                  []string `env:"ADMIN_USERNAMES" envSeparator:","`
+	TrashRetentionDays              int      `env:"TRASH_RETENTION_DAYS" envDefault:"30"`
+	TrashPurgeIntervalMinutes       int      `env:"TRASH_PURGE_INTERVAL_MINUTES" envDefault:"60"`
+	ShareLinkSecret                 string   `env:"SHARE_LINK_SECRET"`
+	SearchBackend                   string   `env:"SEARCH_BACKEND" envDefault:"mongo"`
+	SearchIndexPath                 string   `env:"SEARCH_INDEX_PATH" envDefault:"search.idx"`
+	Embedder                        string   `env:"EMBEDDER" envDefault:"hashing"`
+	EmbeddingDimensions             int      `env:"EMBEDDING_DIMENSIONS" envDefault:"256"`
+	ImportTimeoutSeconds            int      `env:"IMPORT_TIMEOUT_SECONDS" envDefault:"10"`
+	ImportMaxSizeMB                 int      `env:"IMPORT_MAX_SIZE_MB" envDefault:"5"`
+	ImportAllowPrivateHosts         bool     `env:"IMPORT_ALLOW_PRIVATE_HOSTS" envDefault:"false"`
 }
 
 func (c *Config) GetLogLevel() zerolog.Level {
@@ -39,6 +61,29 @@ func (c *Config) GetLogLevel() zerolog.Level {
 	return level
 }
 
+// validate rejects settings that would only fail once the service is running.
+func (c *Config) validate() error {
+	if c.PublishSchedulerIntervalSeconds <= 0 {
+		return errors.New("PUBLISH_SCHEDULER_INTERVAL_SECONDS must be positive")
+	}
+	if c.TrashPurgeIntervalMinutes <= 0 {
+		return errors.New("TRASH_PURGE_INTERVAL_MINUTES must be positive")
+	}
+	// Without a retention period the purge job would empty the trash on its next run.
+	if c.TrashRetentionDays < 1 {
+		return errors.New("TRASH_RETENTION_DAYS must be at least 1")
+	}
+	// Imports without a timeout could hang on a slow server, and without a size none
+	// would be accepted.
+	if c.ImportTimeoutSeconds <= 0 {
+		return errors.New("IMPORT_TIMEOUT_SECONDS must be positive")
+	}
+	if c.ImportMaxSizeMB <= 0 {
+		return errors.New("IMPORT_MAX_SIZE_MB must be positive")
+	}
+	return nil
+}
+
 func loadConfig() {
 	err := godotenv.Load()
 	if err != nil {
@@ -49,6 +94,9 @@ func loadConfig() {
 	if err := env.Parse(config); err != nil {
 		log.Fatal("Error parsing config variables: ", err)
 	}
+	if err := config.validate(); err != nil {
+		log.Fatal("Invalid config: ", err)
+	}
 
 	log.Println("Config loaded successfully")
 }
@@ -13,7 +13,11 @@ import (
 	"go.mongodb.org/mongo-driver/v2/mongo/options"
 
 	"github.com/mahesh-yadav/go-recipes-api/config"
+	"github.com/mahesh-yadav/go-recipes-api/dietary"
 	"github.com/mahesh-yadav/go-recipes-api/models"
+	"github.com/mahesh-yadav/go-recipes-api/nutrition"
+	"github.com/mahesh-yadav/go-recipes-api/steps"
+	"github.com/mahesh-yadav/go-recipes-api/taxonomy"
 )
 
 var mongoClient *mongo.Client
@@ -47,23 +51,46 @@ func GetMongoCollection(config *config.Config, collectionName string) *mongo.Col
 	return client.Database(config.MongoDBName).Collection(collectionName)
 }
 
+// InitDB seeds the collection with the recipes in recipes.json. Seeding is
+// optional, so failures are logged and the API starts without the recipes.
 func InitDB(collection *mongo.Collection) {
-	recipes := make([]models.Recipe, 0)
-	file, _ := os.ReadFile("recipes.json")
-	err := json.Unmarshal(file, &recipes)
+	file, err := os.ReadFile("recipes.json")
 	if err != nil {
-		log.Fatal().Err(err).Msg("Error unmarshalling JSON file")
+		log.Error().Err(err).Msg("Error reading recipes to seed")
+		return
+	}
+	recipes := make([]models.Recipe, 0)
+	if err := json.Unmarshal(file, &recipes); err != nil {
+		log.Error().Err(err).Msg("Error unmarshalling JSON file")
+		return
 	}
 
 	var listOfRecipes []interface{}
 	for _, recipe := range recipes {
+		recipe.Instructions = steps.Structure(recipe.Instructions)
+		estimate := nutrition.Estimate(recipe.Ingredients, recipe.Servings)
+		recipe.Nutrition = &estimate
+		labels := dietary.Detect(recipe.Ingredients)
+		recipe.Allergens = labels.Allergens
+		recipe.Diets = labels.Diets
+		recipe.Tags = taxonomy.New(nil).CanonicalAll(recipe.Tags)
+		recipe.Version = 1
+		recipe.Status = models.StatusPublished
+		if recipe.PublishedAt != nil {
+			recipe.CreatedAt = *recipe.PublishedAt
+		}
+		recipe.UpdatedAt = recipe.CreatedAt
 		listOfRecipes = append(listOfRecipes, recipe)
 	}
 
+	if len(listOfRecipes) == 0 {
+		return
+	}
 	insertManyResult, err := collection.InsertMany(context.Background(), listOfRecipes)
 	if err != nil {
-		log.Fatal().Err(err).Msg("Error inserting documents to MongoDB")
+		log.Error().Err(err).Msg("Error inserting documents to MongoDB")
+		return
 	}
 
-	log.Info().Int("Inserted recipes: ", len(insertManyResult.InsertedIDs))
+	log.Info().Int("recipes", len(insertManyResult.InsertedIDs)).Msg("Seeded recipes")
 }
@@ -0,0 +1,155 @@
+package dietary
+
+import (
+	_ "embed"
+	"encoding/json"
+	"sort"
+	"strings"
+
+	"github.com/mahesh-yadav/go-recipes-api/ingredients"
+)
+
+// rules.json maps each label to the ingredient keywords that trigger it. A keyword
+// starting with "!" is an exception: an ingredient matching it never gets the label,
+// e.g. "!coconut milk" under dairy.
+//
+//go:embed rules.json
+var rulesJSON []byte
+
+type Rules struct {
+	Labels    map[string][]string `json:"labels"`
+	Allergens []string            `json:"allergens"`
+	Diets     map[string][]string `json:"diets"`
+}
+
+type Labels struct {
+	Allergens []string
+	Diets     []string
+}
+
+type keyword struct {
+	tokens    []string
+	exception bool
+}
+
+var (
+	rules    = loadRules()
+	keywords = compileKeywords(rules)
+)
+
+func loadRules() Rules {
+	var rules Rules
+	if err := json.Unmarshal(rulesJSON, &rules); err != nil {
+		panic("invalid embedded dietary rules: " + err.Error())
+	}
+	return rules
+}
+
+func compileKeywords(rules Rules) map[string][]keyword {
+	compiled := make(map[string][]keyword, len(rules.Labels))
+	for label, words := range rules.Labels {
+		for _, word := range words {
+			exception := strings.HasPrefix(word, "!")
+			compiled[label] = append(compiled[label], keyword{
+				tokens:    ingredients.Tokens(strings.TrimPrefix(word, "!")),
+				exception: exception,
+			})
+		}
+	}
+	return compiled
+}
+
+// Allergens returns the allergen names recipes can be flagged with.
+func Allergens() []string {
+	return append([]string(nil), rules.Allergens...)
+}
+
+// Diets returns the dietary label names recipes can be flagged with.
+func Diets() []string {
+	diets := make([]string, 0, len(rules.Diets))
+	for diet := range rules.Diets {
+		diets = append(diets, diet)
+	}
+	sort.Strings(diets)
+	return diets
+}
+
+func IsAllergen(name string) bool {
+	for _, allergen := range rules.Allergens {
+		if allergen == name {
+			return true
+		}
+	}
+	return false
+}
+
+func IsDiet(name string) bool {
+	_, ok := rules.Diets[name]
+	return ok
+}
+
+// Detect derives allergen flags and dietary labels from a recipe's ingredient lines.
+// A recipe gets a diet label when none of its ingredients carries a label the diet excludes.
+func Detect(lines []string) Labels {
+	found := make(map[string]bool)
+	for _, line := range lines {
+		nameTokens := make(map[string]bool)
+		for _, token := range ingredients.Tokens(ingredients.Parse(line).Name) {
+			nameTokens[token] = true
+		}
+		if len(nameTokens) == 0 {
+			continue
+		}
+		for label := range keywords {
+			if matchesLabel(label, nameTokens) {
+				found[label] = true
+			}
+		}
+	}
+
+	labels := Labels{Allergens: make([]string, 0), Diets: make([]string, 0)}
+	for _, allergen := range rules.Allergens {
+		if found[allergen] {
+			labels.Allergens = append(labels.Allergens, allergen)
+		}
+	}
+	for _, diet := range Diets() {
+		compatible := true
+		for _, excluded := range rules.Diets[diet] {
+			if found[excluded] {
+				compatible = false
+				break
+			}
+		}
+		if compatible {
+			labels.Diets = append(labels.Diets, diet)
+		}
+	}
+	return labels
+}
+
+func matchesLabel(label string, nameTokens map[string]bool) bool {
+	matched := false
+	for _, keyword := range keywords[label] {
+		if !containsAll(nameTokens, keyword.tokens) {
+			continue
+		}
+		if keyword.exception {
+			return false
+		}
+		matched = true
+	}
+	return matched
+}
+
+func containsAll(set map[string]bool, tokens []string) bool {
+	if len(tokens) == 0 {
+		return false
+	}
+	for _, token := range tokens {
+		if !set[token] {
+			return false
+		}
+	}
+	return true
+}
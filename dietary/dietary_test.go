@@ -0,0 +1,58 @@
+package dietary
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDetect(t *testing.T) {
+	tests := []struct {
+		name  string
+		lines []string
+		want  Labels
+	}{
+		{
+			name:  "pancakes",
+			lines: []string{"1 1/2 cups all-purpose flour", "1 1/4 cups milk", "1 egg", "3 tbsp butter, melted"},
+			want:  Labels{Allergens: []string{"gluten", "dairy", "eggs"}, Diets: []string{"nut-free", "pescatarian", "vegetarian"}},
+		},
+		{
+			name:  "coconut curry",
+			lines: []string{"1 can coconut milk", "2 tbsp peanut butter", "1 eggplant, cubed", "1 tsp nutmeg", "1 cup rice noodles"},
+			want:  Labels{Allergens: []string{"peanuts"}, Diets: []string{"dairy-free", "gluten-free", "pescatarian", "vegan", "vegetarian"}},
+		},
+		{
+			name:  "pesto salmon",
+			lines: []string{"2 salmon fillets", "1/4 cup basil pesto", "1 tbsp soy sauce"},
+			want:  Labels{Allergens: []string{"gluten", "nuts", "fish", "soy"}, Diets: []string{"dairy-free", "pescatarian"}},
+		},
+		{
+			name:  "no ingredients",
+			lines: []string{"---", ""},
+			want:  Labels{Allergens: []string{}, Diets: []string{"dairy-free", "gluten-free", "nut-free", "pescatarian", "vegan", "vegetarian"}},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := Detect(test.lines); !reflect.DeepEqual(got, test.want) {
+				t.Errorf("Detect() = %+v, want %+v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestLabelNames(t *testing.T) {
+	for _, allergen := range Allergens() {
+		if !IsAllergen(allergen) {
+			t.Errorf("IsAllergen(%q) = false", allergen)
+		}
+	}
+	for _, diet := range Diets() {
+		if !IsDiet(diet) {
+			t.Errorf("IsDiet(%q) = false", diet)
+		}
+	}
+	if IsAllergen("vegan") || IsDiet("gluten") {
+		t.Error("allergens and diets are separate")
+	}
+}
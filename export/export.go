@@ -0,0 +1,99 @@
+// Package export renders recipes in formats other than the API's JSON. Each format
+// registers an Exporter, which handlers look up by name or by Accept header.
+package export
+
+import (
+	"io"
+	"mime"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/mahesh-yadav/go-recipes-api/models"
+)
+
+// Exporter writes a recipe in one format.
+type Exporter interface {
+	ContentType() string
+	Export(w io.Writer, recipe models.ViewRecipe) error
+}
+
+var (
+	mu        sync.RWMutex
+	exporters = make(map[string]Exporter)
+)
+
+// Register makes an exporter available under a format name, replacing any exporter
+// registered under the same name.
+func Register(format string, exporter Exporter) {
+	mu.Lock()
+	defer mu.Unlock()
+	exporters[format] = exporter
+}
+
+func Lookup(format string) (Exporter, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+	exporter, ok := exporters[format]
+	return exporter, ok
+}
+
+// Formats returns the registered format names in alphabetical order.
+func Formats() []string {
+	mu.RLock()
+	defer mu.RUnlock()
+	return sortedFormats()
+}
+
+// Negotiate returns the format of the registered exporter whose media type an Accept
+// header prefers. Wildcards and types without an exporter, such as application/json,
+// match nothing, leaving the choice to the caller.
+func Negotiate(accept string) (string, bool) {
+	type mediaRange struct {
+		mediaType string
+		quality   float64
+	}
+	ranges := make([]mediaRange, 0)
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+		quality := 1.0
+		if q, ok := params["q"]; ok {
+			if quality, err = strconv.ParseFloat(q, 64); err != nil {
+				continue
+			}
+		}
+		if quality > 0 {
+			ranges = append(ranges, mediaRange{mediaType: mediaType, quality: quality})
+		}
+	}
+	sort.SliceStable(ranges, func(i, j int) bool {
+		return ranges[i].quality > ranges[j].quality
+	})
+
+	mu.RLock()
+	defer mu.RUnlock()
+	for _, r := range ranges {
+		for _, format := range sortedFormats() {
+			mediaType, _, _ := mime.ParseMediaType(exporters[format].ContentType())
+			if mediaType == r.mediaType {
+				return format, true
+			}
+		}
+	}
+	return "", false
+}
+
+// sortedFormats makes negotiation deterministic when formats share a media type.
+// The caller must hold mu.
+func sortedFormats() []string {
+	formats := make([]string, 0, len(exporters))
+	for format := range exporters {
+		formats = append(formats, format)
+	}
+	sort.Strings(formats)
+	return formats
+}
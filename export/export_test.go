@@ -0,0 +1,36 @@
+package export
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFormats(t *testing.T) {
+	if want := []string{"jsonld", "markdown", "text"}; !reflect.DeepEqual(Formats(), want) {
+		t.Errorf("Formats() = %v, want %v", Formats(), want)
+	}
+}
+
+func TestNegotiate(t *testing.T) {
+	tests := []struct {
+		accept string
+		format string
+	}{
+		{accept: "text/markdown", format: "markdown"},
+		{accept: "text/plain; charset=utf-8", format: "text"},
+		{accept: "application/ld+json", format: "jsonld"},
+		{accept: "text/plain;q=0.5, text/markdown", format: "markdown"},
+		{accept: "text/markdown;q=0, text/plain", format: "text"},
+		{accept: "application/json, text/markdown;q=0.9", format: "markdown"},
+		{accept: "application/json"},
+		{accept: "*/*"},
+		{accept: "text/markdown;q=abc"},
+		{accept: ""},
+	}
+	for _, test := range tests {
+		format, ok := Negotiate(test.accept)
+		if format != test.format || ok != (test.format != "") {
+			t.Errorf("Negotiate(%q) = %q, %v, want %q", test.accept, format, ok, test.format)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package export
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mahesh-yadav/go-recipes-api/models"
+)
+
+var update = flag.Bool("update", false, "rewrite the expected exports in testdata")
+
+var cookies = models.ViewRecipe{
+	Name:        "Chocolate Chip Cookies",
+	Tags:        []string{"dessert", "baking"},
+	Ingredients: []string{"2 1/4 cups all-purpose flour", "1 cup butter, softened", "2 cups semi-sweet chocolate chips"},
+	Instructions: models.Instructions{
+		{Section: "Dough", Text: "Cream the butter and sugars until fluffy, then beat in the eggs one at a time."},
+		{Section: "Dough", Text: "Stir in the flour and the *chocolate chips*."},
+		{Section: "Baking", Text: "Bake for 9 to 11 minutes."},
+	},
+	Servings:    24,
+	PrepTime:    "PT15M",
+	CookTime:    "PT10M",
+	Cuisine:     "american",
+	Course:      "dessert",
+	Difficulty:  "easy",
+	Author:      "admin",
+	Nutrition:   &models.Nutrition{Calories: 180, ProteinG: 2.1, FatG: 9.4, CarbsG: 23.5, SodiumMg: 95},
+	Attribution: &models.Attribution{Source: "Toll House", Author: "Ruth Wakefield", URL: "https://example.com/cookies"},
+}
+
+func TestExporters(t *testing.T) {
+	tests := []struct {
+		format string
+		golden string
+	}{
+		{format: "markdown", golden: "cookies.md"},
+		{format: "text", golden: "cookies.txt"},
+		{format: "jsonld", golden: "cookies.jsonld"},
+	}
+	for _, test := range tests {
+		t.Run(test.format, func(t *testing.T) {
+			exporter, ok := Lookup(test.format)
+			if !ok {
+				t.Fatalf("no exporter for %s", test.format)
+			}
+			var buffer bytes.Buffer
+			if err := exporter.Export(&buffer, cookies); err != nil {
+				t.Fatal(err)
+			}
+
+			path := filepath.Join("testdata", test.golden)
+			if *update {
+				if err := os.WriteFile(path, buffer.Bytes(), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			want, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(buffer.Bytes(), want) {
+				t.Errorf("export differs from %s:\n%s", path, buffer.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package export
+
+import (
+	"fmt"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/mahesh-yadav/go-recipes-api/isoduration"
+	"github.com/mahesh-yadav/go-recipes-api/models"
+)
+
+// label is a labelled value of a recipe summary, such as "Prep" and "15 min".
+type label struct {
+	name  string
+	value string
+}
+
+// summary returns the times, servings and yield of a recipe that are known.
+func summary(recipe models.ViewRecipe) []label {
+	labels := make([]label, 0)
+	for _, field := range []struct{ name, duration string }{
+		{"Prep", recipe.PrepTime},
+		{"Cook", recipe.CookTime},
+		{"Total", recipe.TotalTime},
+	} {
+		if value := humanDuration(field.duration); value != "" {
+			labels = append(labels, label{field.name, value})
+		}
+	}
+	if recipe.Servings > 0 {
+		labels = append(labels, label{"Serves", fmt.Sprint(recipe.Servings)})
+	}
+	if recipe.Yield != "" {
+		labels = append(labels, label{"Yield", recipe.Yield})
+	}
+	return labels
+}
+
+// details returns the cuisine, course and difficulty of a recipe that are known.
+func details(recipe models.ViewRecipe) []string {
+	details := make([]string, 0, 3)
+	for _, value := range []string{recipe.Cuisine, recipe.Course, recipe.Difficulty} {
+		if value != "" {
+			details = append(details, capitalize(value))
+		}
+	}
+	return details
+}
+
+// capitalize uppercases the first letter of value, which may take several bytes.
+func capitalize(value string) string {
+	r, size := utf8.DecodeRuneInString(value)
+	return string(unicode.ToUpper(r)) + value[size:]
+}
+
+// humanDuration formats an ISO-8601 duration such as PT1H5M as "1 h 5 min".
+func humanDuration(value string) string {
+	duration, err := isoduration.Parse(value)
+	if err != nil || duration <= 0 {
+		return ""
+	}
+	hours := int(duration / time.Hour)
+	minutes := int((duration % time.Hour) / time.Minute)
+	switch {
+	case hours > 0 && minutes > 0:
+		return fmt.Sprintf("%d h %d min", hours, minutes)
+	case hours > 0:
+		return fmt.Sprintf("%d h", hours)
+	}
+	return fmt.Sprintf("%d min", minutes)
+}
+
+// credit returns who a recipe is by and where it comes from.
+func credit(recipe models.ViewRecipe) (author string, source string, url string) {
+	author = recipe.Author
+	if attribution := recipe.Attribution; attribution != nil {
+		if attribution.Author != "" {
+			author = attribution.Author
+		}
+		source, url = attribution.Source, attribution.URL
+	}
+	return author, source, url
+}
+
+func nutritionLines(nutrition *models.Nutrition) []label {
+	if nutrition == nil || nutrition.Calories == 0 {
+		return nil
+	}
+	return []label{
+		{"Calories", fmt.Sprintf("%.0f", nutrition.Calories)},
+		{"Protein", fmt.Sprintf("%.1f g", nutrition.ProteinG)},
+		{"Fat", fmt.Sprintf("%.1f g", nutrition.FatG)},
+		{"Carbohydrates", fmt.Sprintf("%.1f g", nutrition.CarbsG)},
+		{"Sodium", fmt.Sprintf("%.0f mg", nutrition.SodiumMg)},
+	}
+}
@@ -0,0 +1,43 @@
+package export
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/mahesh-yadav/go-recipes-api/models"
+)
+
+func TestHumanDuration(t *testing.T) {
+	tests := map[string]string{
+		"PT15M":   "15 min",
+		"PT1H":    "1 h",
+		"PT1H5M":  "1 h 5 min",
+		"P1DT30M": "24 h 30 min",
+		"PT0S":    "",
+		"soon":    "",
+		"":        "",
+	}
+	for value, want := range tests {
+		if got := humanDuration(value); got != want {
+			t.Errorf("humanDuration(%q) = %q, want %q", value, got, want)
+		}
+	}
+}
+
+func TestDetails(t *testing.T) {
+	recipe := models.ViewRecipe{Cuisine: "éthiopian", Course: "main", Difficulty: "easy"}
+	if want := []string{"Éthiopian", "Main", "Easy"}; !reflect.DeepEqual(details(recipe), want) {
+		t.Errorf("details() = %q, want %q", details(recipe), want)
+	}
+	if got := details(models.ViewRecipe{}); len(got) != 0 {
+		t.Errorf("details() of an empty recipe = %q, want none", got)
+	}
+}
+
+func TestSummary(t *testing.T) {
+	recipe := models.ViewRecipe{PrepTime: "PT15M", TotalTime: "PT1H30M", Servings: 4, Yield: "24 cookies"}
+	want := []label{{"Prep", "15 min"}, {"Total", "1 h 30 min"}, {"Serves", "4"}, {"Yield", "24 cookies"}}
+	if got := summary(recipe); !reflect.DeepEqual(got, want) {
+		t.Errorf("summary() = %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,26 @@
+package export
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/mahesh-yadav/go-recipes-api/models"
+	"github.com/mahesh-yadav/go-recipes-api/schemaorg"
+)
+
+func init() {
+	Register("jsonld", jsonLDExporter{})
+}
+
+// jsonLDExporter writes a schema.org Recipe for search engines and partners.
+type jsonLDExporter struct{}
+
+func (jsonLDExporter) ContentType() string {
+	return "application/ld+json"
+}
+
+func (jsonLDExporter) Export(w io.Writer, recipe models.ViewRecipe) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(schemaorg.FromRecipe(recipe))
+}
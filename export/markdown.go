@@ -0,0 +1,95 @@
+package export
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/mahesh-yadav/go-recipes-api/models"
+	"github.com/mahesh-yadav/go-recipes-api/schemaorg"
+)
+
+func init() {
+	Register("markdown", markdownExporter{})
+}
+
+var markdownEscaper = strings.NewReplacer(
+	`\`, `\\`, "*", `\*`, "_", `\_`, "`", "\\`", "[", `\[`, "]", `\]`, "<", `\<`, ">", `\>`, "#", `\#`, "|", `\|`,
+)
+
+type markdownExporter struct{}
+
+func (markdownExporter) ContentType() string {
+	return "text/markdown; charset=utf-8"
+}
+
+func (markdownExporter) Export(w io.Writer, recipe models.ViewRecipe) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s\n\n", markdownEscaper.Replace(recipe.Name))
+
+	if details := details(recipe); len(details) > 0 {
+		fmt.Fprintf(&b, "*%s*\n\n", markdownEscaper.Replace(strings.Join(details, " · ")))
+	}
+	if labels := summary(recipe); len(labels) > 0 {
+		parts := make([]string, 0, len(labels))
+		for _, label := range labels {
+			parts = append(parts, fmt.Sprintf("**%s:** %s", label.name, markdownEscaper.Replace(label.value)))
+		}
+		fmt.Fprintf(&b, "%s\n\n", strings.Join(parts, " · "))
+	}
+	if len(recipe.Images) > 0 {
+		if url := schemaorg.ImageURL(recipe.Images[0]); url != "" {
+			fmt.Fprintf(&b, "![%s](%s)\n\n", markdownEscaper.Replace(recipe.Name), url)
+		}
+	}
+	if len(recipe.Tags) > 0 {
+		fmt.Fprintf(&b, "Tags: %s\n\n", markdownEscaper.Replace(strings.Join(recipe.Tags, ", ")))
+	}
+
+	b.WriteString("## Ingredients\n\n")
+	for _, ingredient := range recipe.Ingredients {
+		fmt.Fprintf(&b, "- %s\n", markdownEscaper.Replace(ingredient))
+	}
+
+	b.WriteString("\n## Instructions\n")
+	section := ""
+	for i, step := range recipe.Instructions {
+		if step.Section != section || i == 0 {
+			b.WriteString("\n")
+			if step.Section != "" {
+				fmt.Fprintf(&b, "### %s\n\n", markdownEscaper.Replace(step.Section))
+			}
+			section = step.Section
+		}
+		fmt.Fprintf(&b, "%d. %s\n", i+1, markdownEscaper.Replace(step.Text))
+	}
+
+	if lines := nutritionLines(recipe.Nutrition); lines != nil {
+		b.WriteString("\n## Nutrition per serving\n\n| Nutrient | Amount |\n| --- | --- |\n")
+		for _, line := range lines {
+			fmt.Fprintf(&b, "| %s | %s |\n", line.name, line.value)
+		}
+	}
+
+	if author, source, url := credit(recipe); author != "" || source != "" || url != "" {
+		b.WriteString("\n---\n\n")
+		if author != "" {
+			fmt.Fprintf(&b, "By %s", markdownEscaper.Replace(author))
+			if source != "" || url != "" {
+				b.WriteString(", ")
+			}
+		}
+		switch {
+		case url != "" && source != "":
+			fmt.Fprintf(&b, "from [%s](%s)", markdownEscaper.Replace(source), url)
+		case url != "":
+			fmt.Fprintf(&b, "from <%s>", url)
+		case source != "":
+			fmt.Fprintf(&b, "from %s", markdownEscaper.Replace(source))
+		}
+		b.WriteString("\n")
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
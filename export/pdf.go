@@ -0,0 +1,301 @@
+package export
+
+import (
+	"bytes"
+	_ "embed"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/jung-kurt/gofpdf"
+	"github.com/mahesh-yadav/go-recipes-api/models"
+)
+
+// The core PDF fonts only cover Western European text, so a Unicode font is
+// embedded. DejaVu covers Latin, Greek and Cyrillic scripts but not CJK.
+var (
+	//go:embed fonts/DejaVuSansCondensed.ttf
+	dejaVuRegular []byte
+	//go:embed fonts/DejaVuSansCondensed-Bold.ttf
+	dejaVuBold []byte
+	//go:embed fonts/DejaVuSansCondensed-Oblique.ttf
+	dejaVuOblique []byte
+)
+
+const (
+	pdfFont        = "DejaVu"
+	pdfMargin      = 18.0
+	pdfLineHeight  = 5.5
+	pdfColumnGap   = 8.0
+	pdfStepIndent  = 8.0
+	pdfImageHeight = 80.0
+)
+
+// Image is a recipe picture to print, in a format the PDF writer reads: JPG, PNG or GIF.
+type Image struct {
+	Data []byte
+	Type string
+}
+
+// PrintedRecipe is a recipe along with the image printed on its card, if any.
+type PrintedRecipe struct {
+	Recipe models.ViewRecipe
+	Image  *Image
+}
+
+// Cookbook is a collection of recipes printed as one book.
+type Cookbook struct {
+	Name        string
+	Description string
+	Owner       string
+	Recipes     []PrintedRecipe
+}
+
+// RecipePDF writes a printable recipe card.
+func RecipePDF(w io.Writer, recipe PrintedRecipe) error {
+	pdf := newPDF(recipe.Recipe.Name)
+	pdf.SetFooterFunc(func() {
+		footer(pdf, recipe.Recipe)
+	})
+	pdf.AddPage()
+	card(pdf, recipe, "recipe")
+	return pdf.Output(w)
+}
+
+// CookbookPDF writes a cover, a table of contents linking to each recipe and one
+// recipe card per recipe, each starting on a new page.
+func CookbookPDF(w io.Writer, cookbook Cookbook) error {
+	// Page numbers are only known once the recipes are laid out, so a first pass
+	// finds them. The contents take as much room either way, so the layout holds.
+	_, pages := renderCookbook(cookbook, nil)
+	pdf, _ := renderCookbook(cookbook, pages)
+	return pdf.Output(w)
+}
+
+// renderCookbook lays out a cookbook, listing the given page numbers in the
+// contents, and returns the page each recipe starts on.
+func renderCookbook(cookbook Cookbook, pages []int) (*gofpdf.Fpdf, []int) {
+	pdf := newPDF(cookbook.Name)
+	pageWidth, pageHeight := pdf.GetPageSize()
+	contentWidth := pageWidth - 2*pdfMargin
+
+	current := -1
+	pdf.SetFooterFunc(func() {
+		if current >= 0 {
+			footer(pdf, cookbook.Recipes[current].Recipe)
+		} else if pdf.PageNo() > 1 {
+			pageNumber(pdf)
+		}
+	})
+
+	pdf.AddPage()
+	pdf.SetY(pageHeight / 3)
+	pdf.SetFont(pdfFont, "B", 30)
+	pdf.MultiCell(contentWidth, 12, printable(cookbook.Name), "", "C", false)
+	if cookbook.Description != "" {
+		pdf.Ln(6)
+		pdf.SetFont(pdfFont, "", 12)
+		pdf.MultiCell(contentWidth, 6, printable(cookbook.Description), "", "C", false)
+	}
+	if cookbook.Owner != "" {
+		pdf.Ln(10)
+		pdf.SetFont(pdfFont, "I", 11)
+		pdf.CellFormat(contentWidth, 6, printable("Collected by "+cookbook.Owner), "", 1, "C", false, 0, "")
+	}
+
+	pdf.AddPage()
+	heading(pdf, "Contents")
+	links := make([]int, len(cookbook.Recipes))
+	for i, recipe := range cookbook.Recipes {
+		links[i] = pdf.AddLink()
+		page := ""
+		if pages != nil {
+			page = strconv.Itoa(pages[i])
+		}
+		pdf.SetFont(pdfFont, "", 11)
+		name := []rune(printable(recipe.Recipe.Name))
+		for pdf.GetStringWidth(string(name)) > contentWidth-20 && len(name) > 4 {
+			name = append([]rune(strings.TrimSpace(string(name[:len(name)-4]))), '…')
+		}
+		pdf.CellFormat(contentWidth-15, 7, fmt.Sprintf("%d.  %s", i+1, string(name)), "", 0, "L", false, links[i], "")
+		pdf.CellFormat(15, 7, page, "", 1, "R", false, links[i], "")
+	}
+
+	starts := make([]int, len(cookbook.Recipes))
+	for i, recipe := range cookbook.Recipes {
+		pdf.AddPage()
+		current = i
+		starts[i] = pdf.PageNo()
+		pdf.SetLink(links[i], 0, -1)
+		pdf.Bookmark(printable(recipe.Recipe.Name), 0, -1)
+		card(pdf, recipe, fmt.Sprintf("recipe-%d", i))
+	}
+	return pdf, starts
+}
+
+func newPDF(title string) *gofpdf.Fpdf {
+	pdf := gofpdf.New("P", "mm", "A4", "")
+	pdf.AddUTF8FontFromBytes(pdfFont, "", dejaVuRegular)
+	pdf.AddUTF8FontFromBytes(pdfFont, "B", dejaVuBold)
+	pdf.AddUTF8FontFromBytes(pdfFont, "I", dejaVuOblique)
+	pdf.SetMargins(pdfMargin, pdfMargin, pdfMargin)
+	pdf.SetAutoPageBreak(true, pdfMargin+6)
+	pdf.SetTitle(title, true)
+	pdf.SetCreator("Recipes API", true)
+	return pdf
+}
+
+// card lays out a recipe from the current position: title, details, image, the
+// ingredients in two columns, numbered steps and nutrition.
+func card(pdf *gofpdf.Fpdf, printed PrintedRecipe, imageName string) {
+	recipe := printed.Recipe
+	pageWidth, _ := pdf.GetPageSize()
+	contentWidth := pageWidth - 2*pdfMargin
+
+	pdf.SetFont(pdfFont, "B", 22)
+	pdf.MultiCell(contentWidth, 9, printable(recipe.Name), "", "L", false)
+
+	pdf.SetTextColor(100, 100, 100)
+	if details := details(recipe); len(details) > 0 {
+		pdf.SetFont(pdfFont, "I", 10)
+		pdf.CellFormat(contentWidth, 6, printable(strings.Join(details, " · ")), "", 1, "L", false, 0, "")
+	}
+	if labels := summary(recipe); len(labels) > 0 {
+		parts := make([]string, 0, len(labels))
+		for _, label := range labels {
+			parts = append(parts, label.name+": "+label.value)
+		}
+		pdf.SetFont(pdfFont, "", 10)
+		pdf.MultiCell(contentWidth, 5, printable(strings.Join(parts, "    ")), "", "L", false)
+	}
+	pdf.SetTextColor(0, 0, 0)
+	pdf.Ln(3)
+
+	if printed.Image != nil {
+		options := gofpdf.ImageOptions{ImageType: printed.Image.Type}
+		info := pdf.RegisterImageOptionsReader(imageName, options, bytes.NewReader(printed.Image.Data))
+		if pdf.Ok() && info != nil && info.Width() > 0 {
+			width := contentWidth
+			height := width * info.Height() / info.Width()
+			if height > pdfImageHeight {
+				height = pdfImageHeight
+				width = height * info.Width() / info.Height()
+			}
+			pdf.ImageOptions(imageName, pdfMargin+(contentWidth-width)/2, pdf.GetY(), width, height, true, options, 0, "")
+			pdf.Ln(4)
+		}
+		// A broken image must not fail the whole document.
+		pdf.ClearError()
+	}
+
+	heading(pdf, "Ingredients")
+	pdf.SetFont(pdfFont, "", 10.5)
+	columnWidth := (contentWidth - pdfColumnGap) / 2
+	half := (len(recipe.Ingredients) + 1) / 2
+	for row := 0; row < half; row++ {
+		texts := []string{printable("· " + recipe.Ingredients[row])}
+		if row+half < len(recipe.Ingredients) {
+			texts = append(texts, printable("· "+recipe.Ingredients[row+half]))
+		}
+		lines := 0
+		for _, text := range texts {
+			lines = max(lines, len(pdf.SplitText(text, columnWidth)))
+		}
+		height := float64(lines) * pdfLineHeight
+		_, pageHeight := pdf.GetPageSize()
+		if pdf.GetY()+height > pageHeight-pdfMargin-6 {
+			pdf.AddPage()
+		}
+		y := pdf.GetY()
+		for column, text := range texts {
+			pdf.SetXY(pdfMargin+float64(column)*(columnWidth+pdfColumnGap), y)
+			pdf.MultiCell(columnWidth, pdfLineHeight, text, "", "L", false)
+		}
+		pdf.SetXY(pdfMargin, y+height)
+	}
+	pdf.Ln(3)
+
+	heading(pdf, "Instructions")
+	section := ""
+	for i, step := range recipe.Instructions {
+		if step.Section != section && step.Section != "" {
+			pdf.Ln(1)
+			pdf.SetFont(pdfFont, "B", 11)
+			pdf.MultiCell(contentWidth, 6, printable(step.Section), "", "L", false)
+		}
+		section = step.Section
+
+		pdf.SetFont(pdfFont, "B", 10.5)
+		y := pdf.GetY()
+		pdf.CellFormat(pdfStepIndent, pdfLineHeight, fmt.Sprintf("%d.", i+1), "", 0, "L", false, 0, "")
+		pdf.SetFont(pdfFont, "", 10.5)
+		pdf.SetXY(pdfMargin+pdfStepIndent, y)
+		pdf.MultiCell(contentWidth-pdfStepIndent, pdfLineHeight, printable(step.Text), "", "L", false)
+		pdf.Ln(1.5)
+	}
+
+	if lines := nutritionLines(recipe.Nutrition); lines != nil {
+		pdf.Ln(2)
+		heading(pdf, "Nutrition per serving")
+		pdf.SetFont(pdfFont, "", 9.5)
+		width := contentWidth / float64(len(lines))
+		for _, line := range lines {
+			pdf.CellFormat(width, 5, line.name, "", 0, "C", false, 0, "")
+		}
+		pdf.Ln(5)
+		pdf.SetFont(pdfFont, "B", 10)
+		for _, line := range lines {
+			pdf.CellFormat(width, 6, line.value, "", 0, "C", false, 0, "")
+		}
+		pdf.Ln(6)
+	}
+}
+
+func heading(pdf *gofpdf.Fpdf, text string) {
+	pageWidth, _ := pdf.GetPageSize()
+	pdf.SetFont(pdfFont, "B", 13)
+	pdf.CellFormat(pageWidth-2*pdfMargin, 8, text, "B", 1, "L", false, 0, "")
+	pdf.Ln(2)
+}
+
+// footer credits the recipe and numbers the page.
+func footer(pdf *gofpdf.Fpdf, recipe models.ViewRecipe) {
+	pageWidth, _ := pdf.GetPageSize()
+	parts := make([]string, 0, 3)
+	author, source, url := credit(recipe)
+	for _, part := range []string{author, source, url} {
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+
+	pdf.SetY(-pdfMargin)
+	pdf.SetFont(pdfFont, "I", 8)
+	pdf.SetTextColor(120, 120, 120)
+	if len(parts) > 0 {
+		pdf.CellFormat(pageWidth-2*pdfMargin-20, 5, printable("Source: "+strings.Join(parts, ", ")), "", 0, "L", false, 0, "")
+	}
+	pdf.SetTextColor(0, 0, 0)
+	pageNumber(pdf)
+}
+
+func pageNumber(pdf *gofpdf.Fpdf) {
+	pageWidth, _ := pdf.GetPageSize()
+	pdf.SetXY(pageWidth-pdfMargin-20, -pdfMargin)
+	pdf.SetFont(pdfFont, "I", 8)
+	pdf.SetTextColor(120, 120, 120)
+	pdf.CellFormat(20, 5, strconv.Itoa(pdf.PageNo()), "", 0, "R", false, 0, "")
+	pdf.SetTextColor(0, 0, 0)
+}
+
+// printable drops characters beyond the Basic Multilingual Plane, such as emoji,
+// which the PDF writer cannot measure.
+func printable(text string) string {
+	return strings.Map(func(r rune) rune {
+		if r > 0xFFFF {
+			return -1
+		}
+		return r
+	}, text)
+}
@@ -0,0 +1,36 @@
+package export
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestRecipePDF(t *testing.T) {
+	borscht := cookies
+	borscht.Name = "Борщ 🍲"
+	borscht.Ingredients = []string{"свёкла", "капуста", "картофель"}
+
+	for _, recipe := range []PrintedRecipe{{Recipe: cookies}, {Recipe: borscht}} {
+		var buffer bytes.Buffer
+		if err := RecipePDF(&buffer, recipe); err != nil {
+			t.Fatalf("RecipePDF(%q): %v", recipe.Recipe.Name, err)
+		}
+		if !bytes.HasPrefix(buffer.Bytes(), []byte("%PDF-")) {
+			t.Errorf("RecipePDF(%q) did not write a PDF", recipe.Recipe.Name)
+		}
+	}
+}
+
+func TestCookbookPDF(t *testing.T) {
+	cookbook := Cookbook{Name: "Weeknights", Description: "Quick dinners", Owner: "jane"}
+	for range 30 {
+		cookbook.Recipes = append(cookbook.Recipes, PrintedRecipe{Recipe: cookies})
+	}
+	var buffer bytes.Buffer
+	if err := CookbookPDF(&buffer, cookbook); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.HasPrefix(buffer.Bytes(), []byte("%PDF-")) {
+		t.Error("CookbookPDF did not write a PDF")
+	}
+}
@@ -0,0 +1,101 @@
+package export
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/mahesh-yadav/go-recipes-api/models"
+)
+
+func init() {
+	Register("text", textExporter{})
+}
+
+const textWidth = 72
+
+// textExporter writes a plain text recipe meant for printing.
+type textExporter struct{}
+
+func (textExporter) ContentType() string {
+	return "text/plain; charset=utf-8"
+}
+
+func (textExporter) Export(w io.Writer, recipe models.ViewRecipe) error {
+	var b strings.Builder
+	title := strings.ToUpper(recipe.Name)
+	fmt.Fprintf(&b, "%s\n%s\n", title, strings.Repeat("=", len([]rune(title))))
+
+	if details := details(recipe); len(details) > 0 {
+		fmt.Fprintf(&b, "%s\n", strings.Join(details, " | "))
+	}
+	if labels := summary(recipe); len(labels) > 0 {
+		parts := make([]string, 0, len(labels))
+		for _, label := range labels {
+			parts = append(parts, label.name+": "+label.value)
+		}
+		fmt.Fprintf(&b, "%s\n", strings.Join(parts, " | "))
+	}
+
+	b.WriteString("\nINGREDIENTS\n\n")
+	for _, ingredient := range recipe.Ingredients {
+		writeWrapped(&b, "  * ", ingredient)
+	}
+
+	b.WriteString("\nINSTRUCTIONS\n")
+	section := ""
+	for i, step := range recipe.Instructions {
+		if step.Section != section || i == 0 {
+			b.WriteString("\n")
+			if step.Section != "" {
+				fmt.Fprintf(&b, "%s:\n", step.Section)
+			}
+			section = step.Section
+		}
+		writeWrapped(&b, fmt.Sprintf("  %d. ", i+1), step.Text)
+	}
+
+	if lines := nutritionLines(recipe.Nutrition); lines != nil {
+		b.WriteString("\nNUTRITION PER SERVING\n\n")
+		for _, line := range lines {
+			fmt.Fprintf(&b, "  %-15s %s\n", line.name, line.value)
+		}
+	}
+
+	if author, source, url := credit(recipe); author != "" || source != "" || url != "" {
+		parts := make([]string, 0, 3)
+		for _, part := range []string{author, source, url} {
+			if part != "" {
+				parts = append(parts, part)
+			}
+		}
+		fmt.Fprintf(&b, "\nSource: %s\n", strings.Join(parts, ", "))
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// writeWrapped writes text after a prefix, wrapping lines at textWidth and indenting
+// continuation lines to line up with the text.
+func writeWrapped(b *strings.Builder, prefix string, text string) {
+	indent := strings.Repeat(" ", len([]rune(prefix)))
+	line := prefix
+	lineLength := len([]rune(prefix))
+	empty := true
+	for _, word := range strings.Fields(text) {
+		wordLength := len([]rune(word))
+		if !empty && lineLength+1+wordLength > textWidth {
+			b.WriteString(line + "\n")
+			line, lineLength, empty = indent, len([]rune(indent)), true
+		}
+		if !empty {
+			line += " "
+			lineLength++
+		}
+		line += word
+		lineLength += wordLength
+		empty = false
+	}
+	b.WriteString(line + "\n")
+}
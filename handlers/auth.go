@@ -5,6 +5,7 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"net/http"
+	"slices"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -16,6 +17,8 @@ import (
 	"go.mongodb.org/mongo-driver/v2/mongo"
 )
 
+const usernameKey = "username"
+
 type AuthHandler struct {
 	ctx        context.Context
 	config     *config.Config
@@ -209,6 +212,31 @@ func (handler *AuthHandler) AuthMiddlewareJWT() gin.HandlerFunc {
 			})
 			return
 		}
+
+		c.Set(usernameKey, claims.Username)
+		c.Next()
+	}
+}
+
+// AdminMiddleware rejects users not listed in ADMIN_USERNAMES. It must run after AuthMiddlewareJWT.
+func (handler *AuthHandler) AdminMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !isAdmin(handler.config, currentUsername(c)) {
+			c.AbortWithStatusJSON(http.StatusForbidden, models.ErrorResponse{
+				Code:    http.StatusForbidden,
+				Message: "admin access required",
+			})
+			return
+		}
 		c.Next()
 	}
 }
+
+func currentUsername(c *gin.Context) string {
+	return c.GetString(usernameKey)
+}
+
+// isAdmin reports whether username is listed in ADMIN_USERNAMES.
+func isAdmin(config *config.Config, username string) bool {
+	return username != "" && slices.Contains(config.AdminUsernames, username)
+}
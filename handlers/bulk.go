@@ -0,0 +1,307 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+	"github.com/mahesh-yadav/go-recipes-api/bulk"
+	"github.com/mahesh-yadav/go-recipes-api/dietary"
+	"github.com/mahesh-yadav/go-recipes-api/models"
+	"github.com/rs/zerolog/log"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// BulkImportHandler godoc
+//
+//	@Summary		Bulk import recipes
+//	@Description	Import recipes from a JSON Lines or CSV request body, one recipe per line or row. The format is taken from format or else the Content-Type. Rows with an external_id update the recipe imported or exported with the same ID, other rows are created as drafts unless they give a status. Invalid rows are skipped and reported by line. With dry_run the rows are only validated.
+//	@Tags			admin
+//	@Accept			application/x-ndjson,text/csv
+//	@Produce		json
+//	@Param			Authorization	header		string	true	"{token}"
+//	@Param			format			query		string	false	"jsonl or csv"
+//	@Param			dry_run			query		bool	false	"Validate without saving"
+//	@Success		200				{object}	models.BulkImportReport
+//	@Failure		400				{object}	models.ErrorResponse
+//	@Failure		401				{object}	models.ErrorResponse
+//	@Failure		403				{object}	models.ErrorResponse
+//	@Failure		500				{object}	models.ErrorResponse
+//	@Router			/admin/recipes/import [post]
+func (handler *RecipeHandler) BulkImportHandler(c *gin.Context) {
+	var params models.BulkImportParams
+	if err := c.ShouldBindQuery(&params); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Invalid import parameters",
+		})
+		return
+	}
+	if params.Format == "" {
+		params.Format = bulk.FormatOfContentType(c.ContentType())
+	}
+
+	report, err := handler.ImportRecipes(c.Request.Body, params.Format, params.DryRun, currentUsername(c))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Code:    http.StatusBadRequest,
+			Message: fmt.Sprintf("Invalid import: %s", err),
+		})
+		return
+	}
+	c.JSON(http.StatusOK, report)
+}
+
+// BulkExportHandler godoc
+//
+//	@Summary		Bulk export recipes
+//	@Description	Stream every recipe that is not in the trash as JSON Lines or CSV, in the format accepted by the bulk import. Recipes without an external_id are exported with their ID as external_id.
+//	@Tags			admin
+//	@Accept			json
+//	@Produce		application/x-ndjson,text/csv
+//	@Param			Authorization	header	string	true	"{token}"
+//	@Param			format			query	string	false	"jsonl (default) or csv"
+//	@Success		200
+//	@Failure		400	{object}	models.ErrorResponse
+//	@Failure		401	{object}	models.ErrorResponse
+//	@Failure		403	{object}	models.ErrorResponse
+//	@Failure		500	{object}	models.ErrorResponse
+//	@Router			/admin/recipes/export [get]
+func (handler *RecipeHandler) BulkExportHandler(c *gin.Context) {
+	var params models.BulkExportParams
+	if err := c.ShouldBindQuery(&params); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Invalid export parameters",
+		})
+		return
+	}
+	if params.Format == "" {
+		params.Format = bulk.JSONLines
+	}
+
+	c.Header("Content-Type", bulk.ContentType(params.Format))
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", "recipes."+params.Format))
+	c.Status(http.StatusOK)
+	// The status is already sent, so a failure can only cut the stream short.
+	if _, err := handler.ExportRecipes(c.Writer, params.Format); err != nil {
+		log.Error().Err(err).Msg("Error exporting recipes")
+	}
+}
+
+// ImportRecipes reads recipes from a bulk stream and creates or updates them on
+// behalf of editor. Invalid rows are reported and skipped; an error is only
+// returned when the stream itself cannot be read.
+func (handler *RecipeHandler) ImportRecipes(r io.Reader, format string, dryRun bool, editor string) (models.BulkImportReport, error) {
+	report := models.BulkImportReport{DryRun: dryRun, Errors: make([]models.BulkRowError, 0)}
+	reader, err := bulk.NewReader(format, r)
+	if err != nil {
+		return report, err
+	}
+	if !dryRun {
+		// Every row with an external ID looks its recipe up first.
+		index := mongo.IndexModel{Keys: bson.D{{Key: "external_id", Value: 1}}, Options: options.Index().SetSparse(true)}
+		if _, err := handler.collection.Indexes().CreateOne(handler.ctx, index); err != nil {
+			log.Panic().Msg("Error creating external ID index in MongoDB")
+		}
+		defer func() {
+			if report.Created+report.Updated > 0 {
+				handler.RecipesChanged()
+			}
+		}()
+	}
+
+	// A dry run saves nothing, so repeated external IDs are tracked here to report
+	// them as updates the way a real import would.
+	seen := make(map[string]bool)
+	for {
+		row, err := reader.Read()
+		if err == io.EOF {
+			return report, nil
+		}
+		var rowError *bulk.RowError
+		if errors.As(err, &rowError) {
+			report.Rows++
+			report.Failed++
+			report.Errors = append(report.Errors, models.BulkRowError{Line: rowError.Line, Errors: []string{rowError.Err.Error()}})
+			continue
+		} else if err != nil {
+			return report, err
+		}
+
+		report.Rows++
+		created, messages := handler.importRecipe(row.Recipe, dryRun, seen, editor)
+		switch {
+		case messages != nil:
+			report.Failed++
+			report.Errors = append(report.Errors, models.BulkRowError{Line: row.Line, ExternalID: row.Recipe.ExternalID, Errors: messages})
+		case created:
+			report.Created++
+		default:
+			report.Updated++
+		}
+	}
+}
+
+// importRecipe validates and saves one row, reporting whether it created a recipe
+// or the reasons it was rejected.
+func (handler *RecipeHandler) importRecipe(record models.BulkRecipe, dryRun bool, seen map[string]bool, editor string) (bool, []string) {
+	if err := binding.Validator.ValidateStruct(&record); err != nil {
+		return false, validationMessages(err)
+	}
+
+	existing, found := handler.findRecipeByExternalID(record.ExternalID)
+	if !found {
+		recipe, err := handler.newDraft(record.AddUpdateRecipe, editor)
+		if err != nil {
+			return false, []string{err.Error()}
+		}
+		if dryRun {
+			created := !seen[record.ExternalID]
+			seen[record.ExternalID] = record.ExternalID != ""
+			return created, nil
+		}
+
+		recipe.ExternalID = record.ExternalID
+		if record.Status != "" {
+			recipe.Status = record.Status
+		}
+		if recipe.Status == models.StatusPublished {
+			recipe.PublishedAt = &recipe.CreatedAt
+		}
+		if _, err := handler.collection.InsertOne(handler.ctx, recipe); err != nil {
+			log.Panic().Msg("Error inserting recipe into MongoDB")
+		}
+		return true, nil
+	}
+
+	recipe := newRecipe(record.AddUpdateRecipe)
+	recipe.Tags = handler.taxonomy().CanonicalAll(recipe.Tags)
+	if err := validateSteps(recipe.Instructions, len(recipe.Ingredients), existing.Images); err != nil {
+		return false, []string{err.Error()}
+	}
+	if dryRun {
+		return false, nil
+	}
+
+	fields := append(recipeContentFields(recipe), recipeSettingFields(record.AddUpdateRecipe)...)
+	if !existing.LabelsOverridden {
+		labels := dietary.Detect(recipe.Ingredients)
+		fields = append(fields,
+			bson.E{Key: "allergens", Value: labels.Allergens},
+			bson.E{Key: "diets", Value: labels.Diets},
+		)
+	}
+	if record.Status != "" {
+		fields = append(fields, bson.E{Key: "status", Value: record.Status})
+		if record.Status == models.StatusPublished && existing.PublishedAt == nil {
+			fields = append(fields, bson.E{Key: "published_at", Value: time.Now()})
+		}
+	}
+	if _, updated := handler.updateRecipeVersioned(existing, fields, editor); !updated {
+		return false, []string{"recipe was changed during the import"}
+	}
+	return false, nil
+}
+
+func (handler *RecipeHandler) findRecipeByExternalID(externalID string) (models.ViewRecipe, bool) {
+	var recipe models.ViewRecipe
+	if externalID == "" {
+		return recipe, false
+	}
+	filter := bson.D{{Key: "external_id", Value: externalID}, notDeletedFilter()}
+	// Recipes exported without an external ID carry their own ID instead.
+	if objectID, err := bson.ObjectIDFromHex(externalID); err == nil {
+		filter = bson.D{
+			{Key: "$or", Value: bson.A{
+				bson.D{{Key: "external_id", Value: externalID}},
+				bson.D{{Key: "_id", Value: objectID}},
+			}},
+			notDeletedFilter(),
+		}
+	}
+	err := handler.collection.FindOne(handler.ctx, filter).Decode(&recipe)
+	if err == mongo.ErrNoDocuments {
+		return recipe, false
+	} else if err != nil {
+		log.Panic().Msg("Error fetching recipe from MongoDB")
+	}
+	return recipe, true
+}
+
+// ExportRecipes streams every recipe that is not in the trash to w and returns
+// the number of recipes written. Recipes without an external ID are exported
+// with their ID in its place, so importing the export updates them.
+func (handler *RecipeHandler) ExportRecipes(w io.Writer, format string) (int, error) {
+	writer, err := bulk.NewWriter(format, w)
+	if err != nil {
+		return 0, err
+	}
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "_id", Value: 1}}).
+		SetProjection(bson.D{{Key: "embedding", Value: 0}})
+	cursor, err := handler.collection.Find(handler.ctx, bson.D{notDeletedFilter()}, opts)
+	if err != nil {
+		log.Panic().Msg("Error fetching recipes from MongoDB")
+	}
+	defer cursor.Close(handler.ctx)
+
+	count := 0
+	for cursor.Next(handler.ctx) {
+		var recipe models.ViewRecipe
+		if err := cursor.Decode(&recipe); err != nil {
+			log.Panic().Msg("Error decoding recipe from MongoDB")
+		}
+		if recipe.ExternalID == "" {
+			recipe.ExternalID = recipe.ID.Hex()
+		}
+		if err := writer.Write(recipe); err != nil {
+			return count, err
+		}
+		count++
+	}
+	if err := cursor.Err(); err != nil {
+		return count, err
+	}
+	return count, writer.Flush()
+}
+
+// validationMessages describes each failed validation as "field: rule", naming
+// fields the way they are written in the JSON.
+func validationMessages(err error) []string {
+	var validationErrors validator.ValidationErrors
+	if !errors.As(err, &validationErrors) {
+		return []string{err.Error()}
+	}
+	messages := make([]string, 0, len(validationErrors))
+	for _, fieldError := range validationErrors {
+		field := fieldError.Namespace()
+		field = strings.TrimPrefix(field, "BulkRecipe.")
+		field = strings.TrimPrefix(field, "AddUpdateRecipe.")
+		messages = append(messages, fmt.Sprintf("%s: %s", snakeCase(field), fieldError.Tag()))
+	}
+	return messages
+}
+
+func snakeCase(name string) string {
+	var builder strings.Builder
+	previous := rune(0)
+	for _, r := range name {
+		if unicode.IsUpper(r) && unicode.IsLower(previous) {
+			builder.WriteByte('_')
+		}
+		builder.WriteRune(unicode.ToLower(r))
+		previous = r
+	}
+	return builder.String()
+}
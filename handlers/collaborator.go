@@ -0,0 +1,251 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mahesh-yadav/go-recipes-api/models"
+	"github.com/rs/zerolog/log"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// InviteCollaboratorHandler godoc
+//
+//	@Summary		Invite a co-author
+//	@Description	Invite a user to collaborate on a recipe as an editor or viewer. Inviting someone again changes their permission. Only the author can invite.
+//	@Tags			collaborators
+//	@Accept			json
+//	@Produce		json
+//	@Param			id			path		string						true	"Recipe ID"
+//	@Param			invitation	body		models.InviteCollaborator	true	"Invitation"
+//	@Success		200			{object}	models.ViewRecipe
+//	@Failure		400			{object}	models.ErrorResponse
+//	@Failure		403			{object}	models.ErrorResponse
+//	@Failure		404			{object}	models.ErrorResponse
+//	@Failure		500			{object}	models.ErrorResponse
+//	@Router			/recipes/{id}/collaborators [post]
+func (handler *RecipeHandler) InviteCollaboratorHandler(c *gin.Context) {
+	var invitation models.InviteCollaborator
+	if err := c.ShouldBindJSON(&invitation); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Invalid invitation",
+		})
+		return
+	}
+
+	recipe, ok := handler.bindRecipe(c)
+	if !ok {
+		return
+	}
+	username := currentUsername(c)
+	if recipe.Author == "" || recipe.Author != username {
+		c.JSON(http.StatusForbidden, models.ErrorResponse{
+			Code:    http.StatusForbidden,
+			Message: "Only the author can invite collaborators",
+		})
+		return
+	}
+	if invitation.Username == recipe.Author {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Code:    http.StatusBadRequest,
+			Message: "The author cannot be invited to their own recipe",
+		})
+		return
+	}
+
+	var update bson.D
+	filter := bson.D{{Key: "_id", Value: recipe.ID}}
+	if hasCollaborator(recipe, invitation.Username) {
+		filter = append(filter, bson.E{Key: "collaborators.username", Value: invitation.Username})
+		update = bson.D{{Key: "$set", Value: bson.D{
+			{Key: "collaborators.$.permission", Value: invitation.Permission},
+		}}}
+	} else {
+		update = bson.D{{Key: "$push", Value: bson.D{{Key: "collaborators", Value: models.Collaborator{
+			Username:   invitation.Username,
+			Permission: invitation.Permission,
+			InvitedBy:  username,
+			InvitedAt:  time.Now(),
+		}}}}}
+	}
+	if _, err := handler.collection.UpdateOne(handler.ctx, filter, update); err != nil {
+		log.Panic().Msg("Error updating recipe collaborators in MongoDB")
+		return
+	}
+
+	handler.InvalidateCache()
+
+	updated, _ := handler.findRecipe(recipe.ID)
+	c.JSON(http.StatusOK, updated)
+}
+
+// ListInvitationsHandler godoc
+//
+//	@Summary		List my invitations
+//	@Description	Get the recipes the signed in user has been invited to but not joined yet
+//	@Tags			collaborators
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{object}	models.ListRecipes
+//	@Failure		500	{object}	models.ErrorResponse
+//	@Router			/recipes/invitations [get]
+func (handler *RecipeHandler) ListInvitationsHandler(c *gin.Context) {
+	filter := bson.D{
+		{Key: "collaborators", Value: bson.D{{Key: "$elemMatch", Value: bson.D{
+			{Key: "username", Value: currentUsername(c)},
+			{Key: "accepted_at", Value: bson.D{{Key: "$exists", Value: false}}},
+		}}}},
+		notDeletedFilter(),
+	}
+
+	recipes := handler.findRecipes(filter)
+	hideCollaborators(c, recipes)
+	c.JSON(http.StatusOK, models.ListRecipes{
+		Count: len(recipes),
+		Data:  recipes,
+	})
+}
+
+// AcceptInvitationHandler godoc
+//
+//	@Summary		Accept an invitation
+//	@Description	Join a recipe the signed in user has been invited to
+//	@Tags			collaborators
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		string	true	"Recipe ID"
+//	@Success		200	{object}	models.ViewRecipe
+//	@Failure		400	{object}	models.ErrorResponse
+//	@Failure		404	{object}	models.ErrorResponse
+//	@Failure		500	{object}	models.ErrorResponse
+//	@Router			/recipes/{id}/collaborators/accept [post]
+func (handler *RecipeHandler) AcceptInvitationHandler(c *gin.Context) {
+	id := c.Param("id")
+	objectID, err := bson.ObjectIDFromHex(id)
+	if err != nil {
+		log.Error().Str("ID", id).Msg("Invalid Recipe ID")
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Invalid Recipe ID",
+		})
+		return
+	}
+
+	// Invitees cannot view private recipes yet, so the recipe is looked up directly.
+	recipe, found := handler.findRecipe(objectID)
+	if !found || !hasCollaborator(recipe, currentUsername(c)) {
+		c.JSON(http.StatusNotFound, models.ErrorResponse{
+			Code:    http.StatusNotFound,
+			Message: fmt.Sprintf("Invitation not found for recipe with ID: %s", id),
+		})
+		return
+	}
+
+	filter := bson.D{
+		{Key: "_id", Value: objectID},
+		{Key: "collaborators.username", Value: currentUsername(c)},
+	}
+	update := bson.D{{Key: "$set", Value: bson.D{{Key: "collaborators.$.accepted_at", Value: time.Now()}}}}
+	if _, err := handler.collection.UpdateOne(handler.ctx, filter, update); err != nil {
+		log.Panic().Msg("Error updating recipe collaborators in MongoDB")
+		return
+	}
+
+	handler.InvalidateCache()
+
+	updated, _ := handler.findRecipe(objectID)
+	c.JSON(http.StatusOK, updated)
+}
+
+// RemoveCollaboratorHandler godoc
+//
+//	@Summary		Remove a co-author
+//	@Description	Remove a collaborator or withdraw an invitation. The author can remove anyone, collaborators can remove themselves to leave or decline.
+//	@Tags			collaborators
+//	@Accept			json
+//	@Produce		json
+//	@Param			id			path	string	true	"Recipe ID"
+//	@Param			username	path	string	true	"Collaborator username"
+//	@Success		204
+//	@Failure		400	{object}	models.ErrorResponse
+//	@Failure		403	{object}	models.ErrorResponse
+//	@Failure		404	{object}	models.ErrorResponse
+//	@Failure		500	{object}	models.ErrorResponse
+//	@Router			/recipes/{id}/collaborators/{username} [delete]
+func (handler *RecipeHandler) RemoveCollaboratorHandler(c *gin.Context) {
+	id := c.Param("id")
+	collaborator := c.Param("username")
+	objectID, err := bson.ObjectIDFromHex(id)
+	if err != nil {
+		log.Error().Str("ID", id).Msg("Invalid Recipe ID")
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Invalid Recipe ID",
+		})
+		return
+	}
+
+	recipe, found := handler.findRecipe(objectID)
+	if !found || !hasCollaborator(recipe, collaborator) {
+		c.JSON(http.StatusNotFound, models.ErrorResponse{
+			Code:    http.StatusNotFound,
+			Message: fmt.Sprintf("Collaborator not found: %s", collaborator),
+		})
+		return
+	}
+	username := currentUsername(c)
+	if recipe.Author != username && collaborator != username {
+		c.JSON(http.StatusForbidden, models.ErrorResponse{
+			Code:    http.StatusForbidden,
+			Message: "Only the author can remove other collaborators",
+		})
+		return
+	}
+
+	filter := bson.D{{Key: "_id", Value: objectID}}
+	update := bson.D{{Key: "$pull", Value: bson.D{{Key: "collaborators", Value: bson.D{{Key: "username", Value: collaborator}}}}}}
+	if _, err := handler.collection.UpdateOne(handler.ctx, filter, update); err != nil {
+		log.Panic().Msg("Error updating recipe collaborators in MongoDB")
+		return
+	}
+
+	handler.InvalidateCache()
+
+	c.Status(http.StatusNoContent)
+}
+
+// requireEditor responds with 403 unless the current user can edit the recipe. Only
+// admins can edit recipes without an author.
+func (handler *RecipeHandler) requireEditor(c *gin.Context, recipe models.ViewRecipe) bool {
+	username := currentUsername(c)
+	if recipe.CanEdit(username) || (recipe.Author == "" && isAdmin(handler.config, username)) {
+		return true
+	}
+	c.JSON(http.StatusForbidden, models.ErrorResponse{
+		Code:    http.StatusForbidden,
+		Message: "Only the author and editors can change this recipe",
+	})
+	return false
+}
+
+// hideCollaborators strips from each recipe the collaborators the current user may
+// not see.
+func hideCollaborators(c *gin.Context, recipes []models.ViewRecipe) {
+	username := currentUsername(c)
+	for i := range recipes {
+		recipes[i].HideCollaborators(username)
+	}
+}
+
+// hasCollaborator reports whether username is invited to the recipe, accepted or not.
+func hasCollaborator(recipe models.ViewRecipe, username string) bool {
+	for _, collaborator := range recipe.Collaborators {
+		if collaborator.Username == username {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,365 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mahesh-yadav/go-recipes-api/export"
+	"github.com/mahesh-yadav/go-recipes-api/models"
+	"github.com/mahesh-yadav/go-recipes-api/storage"
+	"github.com/rs/zerolog/log"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+type CookbookHandler struct {
+	ctx              context.Context
+	collection       *mongo.Collection
+	recipeCollection *mongo.Collection
+	blobStore        storage.BlobStore
+}
+
+func NewCookbookHandler(ctx context.Context, collection *mongo.Collection, recipeCollection *mongo.Collection, blobStore storage.BlobStore) *CookbookHandler {
+	return &CookbookHandler{
+		ctx:              ctx,
+		collection:       collection,
+		recipeCollection: recipeCollection,
+		blobStore:        blobStore,
+	}
+}
+
+// CreateCookbookHandler godoc
+//
+//	@Summary		Create a cookbook
+//	@Description	Collect recipes the signed in user can view into a cookbook, in the given order
+//	@Tags			cookbooks
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header		string						true	"{token}"
+//	@Param			cookbook		body		models.AddUpdateCookbook	true	"Cookbook"
+//	@Success		201				{object}	models.Cookbook
+//	@Failure		400				{object}	models.ErrorResponse
+//	@Failure		401				{object}	models.ErrorResponse
+//	@Failure		500				{object}	models.ErrorResponse
+//	@Router			/cookbooks [post]
+func (handler *CookbookHandler) CreateCookbookHandler(c *gin.Context) {
+	var addCookbook models.AddUpdateCookbook
+	if err := c.ShouldBindJSON(&addCookbook); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Invalid Cookbook data",
+		})
+		return
+	}
+
+	username := currentUsername(c)
+	recipeIDs, ok := handler.cookbookRecipeIDs(c, addCookbook.RecipeIDs, username)
+	if !ok {
+		return
+	}
+
+	now := time.Now()
+	cookbook := models.Cookbook{
+		ID:          bson.NewObjectID(),
+		Name:        addCookbook.Name,
+		Description: addCookbook.Description,
+		Owner:       username,
+		RecipeIDs:   recipeIDs,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	if _, err := handler.collection.InsertOne(handler.ctx, cookbook); err != nil {
+		log.Panic().Msg("Error inserting cookbook in MongoDB")
+		return
+	}
+
+	c.JSON(http.StatusCreated, cookbook)
+}
+
+// ListCookbooksHandler godoc
+//
+//	@Summary		List my cookbooks
+//	@Description	List the cookbooks of the signed in user, most recently updated first
+//	@Tags			cookbooks
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header		string	true	"{token}"
+//	@Success		200				{object}	models.ListCookbooks
+//	@Failure		401				{object}	models.ErrorResponse
+//	@Failure		500				{object}	models.ErrorResponse
+//	@Router			/cookbooks [get]
+func (handler *CookbookHandler) ListCookbooksHandler(c *gin.Context) {
+	filter := bson.D{{Key: "owner", Value: currentUsername(c)}}
+	opts := options.Find().SetSort(bson.D{{Key: "updated_at", Value: -1}})
+	cursor, err := handler.collection.Find(handler.ctx, filter, opts)
+	if err != nil {
+		log.Panic().Msg("Error fetching cookbooks from MongoDB")
+		return
+	}
+	defer cursor.Close(handler.ctx)
+
+	cookbooks := make([]models.Cookbook, 0)
+	if err := cursor.All(handler.ctx, &cookbooks); err != nil {
+		log.Panic().Msg("Error decoding cookbooks from MongoDB")
+		return
+	}
+
+	c.JSON(http.StatusOK, models.ListCookbooks{
+		Count: len(cookbooks),
+		Data:  cookbooks,
+	})
+}
+
+// GetCookbookHandler godoc
+//
+//	@Summary		Get a cookbook
+//	@Description	Get one of the signed in user's cookbooks
+//	@Tags			cookbooks
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header		string	true	"{token}"
+//	@Param			id				path		string	true	"Cookbook ID"
+//	@Success		200				{object}	models.Cookbook
+//	@Failure		400				{object}	models.ErrorResponse
+//	@Failure		401				{object}	models.ErrorResponse
+//	@Failure		404				{object}	models.ErrorResponse
+//	@Failure		500				{object}	models.ErrorResponse
+//	@Router			/cookbooks/{id} [get]
+func (handler *CookbookHandler) GetCookbookHandler(c *gin.Context) {
+	if strings.HasSuffix(c.Param("id"), ".pdf") {
+		handler.GetCookbookPDFHandler(c)
+		return
+	}
+
+	cookbook, ok := handler.findCookbook(c, c.Param("id"))
+	if !ok {
+		return
+	}
+	c.JSON(http.StatusOK, cookbook)
+}
+
+// UpdateCookbookHandler godoc
+//
+//	@Summary		Update a cookbook
+//	@Description	Replace the name, description and recipes of one of the signed in user's cookbooks
+//	@Tags			cookbooks
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header		string						true	"{token}"
+//	@Param			id				path		string						true	"Cookbook ID"
+//	@Param			cookbook		body		models.AddUpdateCookbook	true	"Cookbook"
+//	@Success		200				{object}	models.Cookbook
+//	@Failure		400				{object}	models.ErrorResponse
+//	@Failure		401				{object}	models.ErrorResponse
+//	@Failure		404				{object}	models.ErrorResponse
+//	@Failure		500				{object}	models.ErrorResponse
+//	@Router			/cookbooks/{id} [put]
+func (handler *CookbookHandler) UpdateCookbookHandler(c *gin.Context) {
+	cookbook, ok := handler.findCookbook(c, c.Param("id"))
+	if !ok {
+		return
+	}
+
+	var updateCookbook models.AddUpdateCookbook
+	if err := c.ShouldBindJSON(&updateCookbook); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Invalid Cookbook data",
+		})
+		return
+	}
+	recipeIDs, ok := handler.cookbookRecipeIDs(c, updateCookbook.RecipeIDs, cookbook.Owner)
+	if !ok {
+		return
+	}
+
+	cookbook.Name = updateCookbook.Name
+	cookbook.Description = updateCookbook.Description
+	cookbook.RecipeIDs = recipeIDs
+	cookbook.UpdatedAt = time.Now()
+
+	filter := bson.D{{Key: "_id", Value: cookbook.ID}}
+	if _, err := handler.collection.ReplaceOne(handler.ctx, filter, cookbook); err != nil {
+		log.Panic().Msg("Error updating cookbook in MongoDB")
+		return
+	}
+
+	c.JSON(http.StatusOK, cookbook)
+}
+
+// DeleteCookbookHandler godoc
+//
+//	@Summary		Delete a cookbook
+//	@Description	Delete one of the signed in user's cookbooks. Its recipes are left untouched.
+//	@Tags			cookbooks
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header	string	true	"{token}"
+//	@Param			id				path	string	true	"Cookbook ID"
+//	@Success		204
+//	@Failure		400	{object}	models.ErrorResponse
+//	@Failure		401	{object}	models.ErrorResponse
+//	@Failure		404	{object}	models.ErrorResponse
+//	@Failure		500	{object}	models.ErrorResponse
+//	@Router			/cookbooks/{id} [delete]
+func (handler *CookbookHandler) DeleteCookbookHandler(c *gin.Context) {
+	cookbook, ok := handler.findCookbook(c, c.Param("id"))
+	if !ok {
+		return
+	}
+
+	if _, err := handler.collection.DeleteOne(handler.ctx, bson.D{{Key: "_id", Value: cookbook.ID}}); err != nil {
+		log.Panic().Msg("Error deleting cookbook from MongoDB")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetCookbookPDFHandler godoc
+//
+//	@Summary		Print a cookbook
+//	@Description	Render a cookbook as a PDF with a cover, a linked table of contents and one recipe card per recipe. Recipes that were deleted or are no longer visible to the owner are left out.
+//	@Tags			cookbooks
+//	@Accept			json
+//	@Produce		application/pdf
+//	@Param			Authorization	header		string	true	"{token}"
+//	@Param			id				path		string	true	"Cookbook ID"
+//	@Param			Accept-Language	header		string	false	"Preferred locales, e.g. es, en;q=0.8"
+//	@Success		200				{file}		file
+//	@Failure		400				{object}	models.ErrorResponse
+//	@Failure		401				{object}	models.ErrorResponse
+//	@Failure		404				{object}	models.ErrorResponse
+//	@Failure		500				{object}	models.ErrorResponse
+//	@Router			/cookbooks/{id}.pdf [get]
+func (handler *CookbookHandler) GetCookbookPDFHandler(c *gin.Context) {
+	cookbook, ok := handler.findCookbook(c, strings.TrimSuffix(c.Param("id"), ".pdf"))
+	if !ok {
+		return
+	}
+
+	recipes := handler.cookbookRecipes(cookbook)
+	localizeRecipes(c, recipes)
+
+	book := export.Cookbook{
+		Name:        cookbook.Name,
+		Description: cookbook.Description,
+		Owner:       cookbook.Owner,
+		Recipes:     make([]export.PrintedRecipe, 0, len(recipes)),
+	}
+	for _, recipe := range recipes {
+		book.Recipes = append(book.Recipes, export.PrintedRecipe{
+			Recipe: recipe,
+			Image:  printImage(handler.ctx, handler.blobStore, recipe),
+		})
+	}
+
+	var body bytes.Buffer
+	if err := export.CookbookPDF(&body, book); err != nil {
+		log.Panic().Err(err).Str("ID", cookbook.ID.Hex()).Msg("Error rendering cookbook PDF")
+		return
+	}
+	writePDF(c, cookbook.ID.Hex(), body.Bytes())
+}
+
+// findCookbook loads a cookbook of the signed in user, responding with an error
+// when the ID is invalid or the cookbook belongs to someone else.
+func (handler *CookbookHandler) findCookbook(c *gin.Context, id string) (models.Cookbook, bool) {
+	var cookbook models.Cookbook
+	objectID, err := bson.ObjectIDFromHex(id)
+	if err != nil {
+		log.Error().Str("ID", id).Msg("Invalid Cookbook ID")
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Invalid Cookbook ID",
+		})
+		return cookbook, false
+	}
+
+	filter := bson.D{{Key: "_id", Value: objectID}, {Key: "owner", Value: currentUsername(c)}}
+	err = handler.collection.FindOne(handler.ctx, filter).Decode(&cookbook)
+	if err == mongo.ErrNoDocuments {
+		c.JSON(http.StatusNotFound, models.ErrorResponse{
+			Code:    http.StatusNotFound,
+			Message: fmt.Sprintf("Cookbook not found with ID: %s", id),
+		})
+		return cookbook, false
+	} else if err != nil {
+		log.Panic().Msg("Error fetching cookbook from MongoDB")
+	}
+	return cookbook, true
+}
+
+// cookbookRecipeIDs parses the recipe IDs of a cookbook, dropping duplicates, and
+// checks that every recipe exists and can be viewed by the owner.
+func (handler *CookbookHandler) cookbookRecipeIDs(c *gin.Context, ids []string, owner string) ([]bson.ObjectID, bool) {
+	objectIDs := make([]bson.ObjectID, 0, len(ids))
+	seen := make(map[bson.ObjectID]bool, len(ids))
+	for _, id := range ids {
+		objectID, err := bson.ObjectIDFromHex(id)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, models.ErrorResponse{
+				Code:    http.StatusBadRequest,
+				Message: fmt.Sprintf("Invalid Recipe ID: %s", id),
+			})
+			return nil, false
+		}
+		if !seen[objectID] {
+			seen[objectID] = true
+			objectIDs = append(objectIDs, objectID)
+		}
+	}
+
+	viewable := make(map[bson.ObjectID]bool, len(objectIDs))
+	for _, recipe := range handler.findRecipes(objectIDs) {
+		viewable[recipe.ID] = recipe.CanView(owner)
+	}
+	for _, objectID := range objectIDs {
+		if !viewable[objectID] {
+			c.JSON(http.StatusBadRequest, models.ErrorResponse{
+				Code:    http.StatusBadRequest,
+				Message: fmt.Sprintf("Recipe not found with ID: %s", objectID.Hex()),
+			})
+			return nil, false
+		}
+	}
+	return objectIDs, true
+}
+
+// cookbookRecipes returns the recipes of a cookbook the owner can still view, in
+// the cookbook's order.
+func (handler *CookbookHandler) cookbookRecipes(cookbook models.Cookbook) []models.ViewRecipe {
+	byID := make(map[bson.ObjectID]models.ViewRecipe, len(cookbook.RecipeIDs))
+	for _, recipe := range handler.findRecipes(cookbook.RecipeIDs) {
+		byID[recipe.ID] = recipe
+	}
+
+	recipes := make([]models.ViewRecipe, 0, len(cookbook.RecipeIDs))
+	for _, objectID := range cookbook.RecipeIDs {
+		if recipe, ok := byID[objectID]; ok && recipe.CanView(cookbook.Owner) {
+			recipes = append(recipes, recipe)
+		}
+	}
+	return recipes
+}
+
+func (handler *CookbookHandler) findRecipes(objectIDs []bson.ObjectID) []models.ViewRecipe {
+	filter := bson.D{{Key: "_id", Value: bson.D{{Key: "$in", Value: objectIDs}}}, notDeletedFilter()}
+	cursor, err := handler.recipeCollection.Find(handler.ctx, filter)
+	if err != nil {
+		log.Panic().Msg("Error fetching recipes from MongoDB")
+	}
+	defer cursor.Close(handler.ctx)
+
+	recipes := make([]models.ViewRecipe, 0, len(objectIDs))
+	if err := cursor.All(handler.ctx, &recipes); err != nil {
+		log.Panic().Msg("Error decoding recipes from MongoDB")
+	}
+	return recipes
+}
@@ -0,0 +1,46 @@
+package handlers
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mahesh-yadav/go-recipes-api/export"
+	"github.com/mahesh-yadav/go-recipes-api/models"
+	"github.com/rs/zerolog/log"
+)
+
+// jsonFormat is the API's own representation, used when no exporter is asked for.
+const jsonFormat = "json"
+
+// writeRecipe responds with a recipe in the format named by ?format= or, without
+// it, the one preferred by the Accept header, falling back to the API's JSON.
+func writeRecipe(c *gin.Context, recipe models.ViewRecipe) {
+	c.Header("Vary", "Accept, Accept-Language")
+	format := c.Query("format")
+	if format == "" {
+		format, _ = export.Negotiate(c.GetHeader("Accept"))
+	}
+	if format == "" || format == jsonFormat {
+		c.JSON(http.StatusOK, recipe)
+		return
+	}
+
+	exporter, ok := export.Lookup(format)
+	if !ok {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Code:    http.StatusBadRequest,
+			Message: fmt.Sprintf("Unknown format: %s, expected one of %s", format, strings.Join(append([]string{jsonFormat}, export.Formats()...), ", ")),
+		})
+		return
+	}
+
+	var body bytes.Buffer
+	if err := exporter.Export(&body, recipe); err != nil {
+		log.Panic().Err(err).Str("format", format).Msg("Error exporting recipe")
+		return
+	}
+	c.Data(http.StatusOK, exporter.ContentType(), body.Bytes())
+}
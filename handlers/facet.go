@@ -0,0 +1,173 @@
+package handlers
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/mahesh-yadav/go-recipes-api/models"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+const (
+	defaultSearchPageSize = 20
+	maxTagFacetValues     = 50
+)
+
+// timeBuckets group total times into ranges of minutes, upper bound included.
+var timeBuckets = []struct {
+	key      string
+	min, max int
+}{
+	{"under_15m", 1, 15},
+	{"15_30m", 16, 30},
+	{"30_60m", 31, 60},
+	{"over_60m", 61, 0},
+}
+
+// facetFilters holds the multi-select filters of a faceted search. Values within a
+// facet are combined with OR, facets with AND.
+type facetFilters struct {
+	tags       []string
+	cuisine    []string
+	difficulty []string
+	totalTime  []string
+}
+
+func (filters facetFilters) match(except string) bson.D {
+	match := bson.D{}
+	if len(filters.tags) > 0 && except != "tags" {
+		match = append(match, bson.E{Key: "tags", Value: bson.D{{Key: "$in", Value: filters.tags}}})
+	}
+	if len(filters.cuisine) > 0 && except != "cuisine" {
+		match = append(match, matchAny("cuisine", filters.cuisine))
+	}
+	if len(filters.difficulty) > 0 && except != "difficulty" {
+		match = append(match, matchAny("difficulty", filters.difficulty))
+	}
+	if len(filters.totalTime) > 0 && except != "total_time" {
+		ranges := bson.A{}
+		for _, bucket := range timeBuckets {
+			if slices.Contains(filters.totalTime, bucket.key) {
+				minutes := bson.D{{Key: "$gte", Value: bucket.min}}
+				if bucket.max > 0 {
+					minutes = append(minutes, bson.E{Key: "$lte", Value: bucket.max})
+				}
+				ranges = append(ranges, bson.D{{Key: "total_time_minutes", Value: minutes}})
+			}
+		}
+		match = append(match, bson.E{Key: "$or", Value: ranges})
+	}
+	return match
+}
+
+func parseTimeBuckets(value string) ([]string, error) {
+	keys := make([]string, 0, len(timeBuckets))
+	for _, bucket := range timeBuckets {
+		keys = append(keys, bucket.key)
+	}
+	buckets := splitList(value)
+	for _, bucket := range buckets {
+		if !slices.Contains(keys, bucket) {
+			return nil, fmt.Errorf("Unknown total_time: %s, expected one of %s", bucket, strings.Join(keys, ", "))
+		}
+	}
+	return buckets, nil
+}
+
+// facetPipeline pages the recipes matching base and filters and counts the facet
+// values in a single $facet stage.
+func facetPipeline(base bson.D, filters facetFilters, page, pageSize int) mongo.Pipeline {
+	branches := bson.A{}
+	for _, bucket := range timeBuckets {
+		condition := bson.A{bson.D{{Key: "$gte", Value: bson.A{"$total_time_minutes", bucket.min}}}}
+		if bucket.max > 0 {
+			condition = append(condition, bson.D{{Key: "$lte", Value: bson.A{"$total_time_minutes", bucket.max}}})
+		}
+		branches = append(branches, bson.D{
+			{Key: "case", Value: bson.D{{Key: "$and", Value: condition}}},
+			{Key: "then", Value: bucket.key},
+		})
+	}
+
+	return mongo.Pipeline{
+		{{Key: "$match", Value: base}},
+		{{Key: "$facet", Value: bson.D{
+			{Key: "results", Value: bson.A{
+				bson.D{{Key: "$match", Value: filters.match("")}},
+				bson.D{{Key: "$sort", Value: bson.D{{Key: "_id", Value: 1}}}},
+				bson.D{{Key: "$skip", Value: (page - 1) * pageSize}},
+				bson.D{{Key: "$limit", Value: pageSize}},
+			}},
+			{Key: "total", Value: bson.A{
+				bson.D{{Key: "$match", Value: filters.match("")}},
+				bson.D{{Key: "$count", Value: "count"}},
+			}},
+			{Key: "tags", Value: bson.A{
+				bson.D{{Key: "$match", Value: filters.match("tags")}},
+				bson.D{{Key: "$unwind", Value: "$tags"}},
+				facetCount("$tags"),
+				facetSort(),
+				bson.D{{Key: "$limit", Value: maxTagFacetValues}},
+			}},
+			{Key: "cuisine", Value: bson.A{
+				bson.D{{Key: "$match", Value: append(filters.match("cuisine"), bson.E{Key: "cuisine", Value: bson.D{{Key: "$nin", Value: bson.A{nil, ""}}}})}},
+				facetCount(bson.D{{Key: "$toLower", Value: "$cuisine"}}),
+				facetSort(),
+			}},
+			{Key: "difficulty", Value: bson.A{
+				bson.D{{Key: "$match", Value: append(filters.match("difficulty"), bson.E{Key: "difficulty", Value: bson.D{{Key: "$nin", Value: bson.A{nil, ""}}}})}},
+				facetCount("$difficulty"),
+				facetSort(),
+			}},
+			{Key: "total_time", Value: bson.A{
+				bson.D{{Key: "$match", Value: append(filters.match("total_time"), bson.E{Key: "total_time_minutes", Value: bson.D{{Key: "$gt", Value: 0}}})}},
+				facetCount(bson.D{{Key: "$switch", Value: bson.D{{Key: "branches", Value: branches}, {Key: "default", Value: ""}}}}),
+			}},
+		}}},
+	}
+}
+
+func facetCount(value interface{}) bson.D {
+	return bson.D{{Key: "$group", Value: bson.D{
+		{Key: "_id", Value: value},
+		{Key: "count", Value: bson.D{{Key: "$sum", Value: 1}}},
+	}}}
+}
+
+func facetSort() bson.D {
+	return bson.D{{Key: "$sort", Value: bson.D{{Key: "count", Value: -1}, {Key: "_id", Value: 1}}}}
+}
+
+// markSelected flags the selected facet values, listing selections without matches
+// with a count of zero so they can still be deselected.
+func markSelected(values []models.FacetValue, selected []string) []models.FacetValue {
+	if values == nil {
+		values = make([]models.FacetValue, 0)
+	}
+	found := make(map[string]bool, len(values))
+	for i := range values {
+		values[i].Selected = slices.Contains(selected, values[i].Value)
+		found[values[i].Value] = true
+	}
+	for _, value := range selected {
+		if !found[value] {
+			values = append(values, models.FacetValue{Value: value, Selected: true})
+		}
+	}
+	return values
+}
+
+// sortTimeBuckets orders time facet values from shortest to longest.
+func sortTimeBuckets(values []models.FacetValue) []models.FacetValue {
+	sorted := make([]models.FacetValue, 0, len(values))
+	for _, bucket := range timeBuckets {
+		for _, value := range values {
+			if value.Value == bucket.key {
+				sorted = append(sorted, value)
+			}
+		}
+	}
+	return sorted
+}
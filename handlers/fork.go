@@ -0,0 +1,195 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mahesh-yadav/go-recipes-api/models"
+	"github.com/rs/zerolog/log"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+// ForkRecipeHandler godoc
+//
+//	@Summary		Fork a recipe
+//	@Description	Copy a recipe into a new draft owned by the signed in user. The fork references the original and credits its author. Images are not copied.
+//	@Tags			recipes
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		string	true	"Recipe ID"
+//	@Success		201	{object}	models.ViewRecipe
+//	@Failure		400	{object}	models.ErrorResponse
+//	@Failure		404	{object}	models.ErrorResponse
+//	@Failure		500	{object}	models.ErrorResponse
+//	@Router			/recipes/{id}/fork [post]
+func (handler *RecipeHandler) ForkRecipeHandler(c *gin.Context) {
+	original, ok := handler.bindRecipe(c)
+	if !ok {
+		return
+	}
+
+	username := currentUsername(c)
+	if !original.IsPublished() && original.Author != username {
+		c.JSON(http.StatusNotFound, models.ErrorResponse{
+			Code:    http.StatusNotFound,
+			Message: fmt.Sprintf("Recipe not found with ID: %s", original.ID.Hex()),
+		})
+		return
+	}
+
+	recipe := recipeContent(original)
+	recipe.Instructions = make(models.Instructions, len(original.Instructions))
+	for i, step := range original.Instructions {
+		// Images belong to the original recipe.
+		step.ImageID = ""
+		recipe.Instructions[i] = step
+	}
+	recipe.Attribution = forkAttribution(original)
+	recipe.Author = username
+	// A fork is never more visible than its original.
+	if recipe.Visibility == "" {
+		recipe.Visibility = models.VisibilityPublic
+	}
+	recipe.Allergens = original.Allergens
+	recipe.Diets = original.Diets
+	recipe.LabelsOverridden = original.LabelsOverridden
+	recipe.Version = 1
+	recipe.Status = models.StatusDraft
+	recipe.CreatedAt = time.Now()
+	recipe.UpdatedAt = recipe.CreatedAt
+	recipe.ForkedFrom = &original.ID
+
+	result, err := handler.collection.InsertOne(handler.ctx, recipe)
+	if err != nil {
+		log.Panic().Msg("Error inserting recipe into MongoDB")
+		return
+	}
+
+	handler.countFork(recipe.ForkedFrom, 1)
+	handler.InvalidateCache()
+	fork, _ := handler.findRecipe(result.InsertedID.(bson.ObjectID))
+	c.JSON(http.StatusCreated, fork)
+}
+
+// RecipeLineageHandler godoc
+//
+//	@Summary		Get the fork lineage of a recipe
+//	@Description	Get the recipes a recipe was forked from and the forks made from it. Deleted recipes and recipes the user cannot view are left out.
+//	@Tags			recipes
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		string	true	"Recipe ID"
+//	@Success		200	{object}	models.RecipeLineage
+//	@Failure		400	{object}	models.ErrorResponse
+//	@Failure		404	{object}	models.ErrorResponse
+//	@Failure		500	{object}	models.ErrorResponse
+//	@Router			/recipes/{id}/lineage [get]
+func (handler *RecipeHandler) RecipeLineageHandler(c *gin.Context) {
+	recipe, ok := handler.bindRecipe(c)
+	if !ok {
+		return
+	}
+
+	username := currentUsername(c)
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.D{{Key: "_id", Value: recipe.ID}}}},
+		{{Key: "$graphLookup", Value: bson.D{
+			{Key: "from", Value: handler.collection.Name()},
+			{Key: "startWith", Value: "$forked_from"},
+			{Key: "connectFromField", Value: "forked_from"},
+			{Key: "connectToField", Value: "_id"},
+			{Key: "as", Value: "ancestors"},
+			{Key: "depthField", Value: "depth"},
+		}}},
+		{{Key: "$graphLookup", Value: bson.D{
+			{Key: "from", Value: handler.collection.Name()},
+			{Key: "startWith", Value: "$_id"},
+			{Key: "connectFromField", Value: "_id"},
+			{Key: "connectToField", Value: "forked_from"},
+			{Key: "as", Value: "descendants"},
+			{Key: "depthField", Value: "depth"},
+		}}},
+	}
+	cursor, err := handler.collection.Aggregate(handler.ctx, pipeline)
+	if err != nil {
+		log.Panic().Msg("Error fetching recipe lineage from MongoDB")
+		return
+	}
+	defer cursor.Close(handler.ctx)
+
+	var results []struct {
+		models.LineageRecipe `bson:",inline"`
+		Ancestors            []models.LineageRecipe `bson:"ancestors"`
+		Descendants          []models.LineageRecipe `bson:"descendants"`
+	}
+	if err := cursor.All(handler.ctx, &results); err != nil || len(results) == 0 {
+		log.Panic().Msg("Error decoding recipe lineage from MongoDB")
+		return
+	}
+
+	result := results[0]
+	c.JSON(http.StatusOK, models.RecipeLineage{
+		Recipe:      result.LineageRecipe,
+		Ancestors:   visibleLineage(result.Ancestors, username),
+		Descendants: visibleLineage(result.Descendants, username),
+	})
+}
+
+// countFork adds delta to the fork count of the recipe a fork was made from, so that
+// forks in the trash are not counted. It does nothing for recipes that are not forks.
+func (handler *RecipeHandler) countFork(forkedFrom *bson.ObjectID, delta int) {
+	if forkedFrom == nil {
+		return
+	}
+	filter := bson.D{{Key: "_id", Value: *forkedFrom}}
+	update := bson.D{{Key: "$inc", Value: bson.D{{Key: "fork_count", Value: delta}}}}
+	if _, err := handler.collection.UpdateOne(handler.ctx, filter, update); err != nil {
+		log.Panic().Msg("Error updating recipe fork count in MongoDB")
+	}
+}
+
+// forkAttribution credits the original recipe, keeping any source it already credits.
+func forkAttribution(original models.ViewRecipe) *models.Attribution {
+	attribution := models.Attribution{}
+	if original.Attribution != nil {
+		attribution = *original.Attribution
+	}
+	if attribution.Author == "" {
+		attribution.Author = original.Author
+	}
+	if attribution.Source == "" {
+		attribution.Source = fmt.Sprintf("Forked from %s", original.Name)
+	}
+	return &attribution
+}
+
+// visibleLineage drops recipes the user cannot see and orders the rest by depth.
+// $graphLookup depths start at 0, lineage depths at 1.
+func visibleLineage(recipes []models.LineageRecipe, username string) []models.LineageRecipe {
+	visible := make([]models.LineageRecipe, 0, len(recipes))
+	for _, recipe := range recipes {
+		if recipe.DeletedAt != nil {
+			continue
+		}
+		view := models.ViewRecipe{
+			Author:        recipe.Author,
+			Status:        recipe.Status,
+			Visibility:    recipe.Visibility,
+			Collaborators: recipe.Collaborators,
+		}
+		if !view.CanView(username) {
+			continue
+		}
+		recipe.Depth++
+		visible = append(visible, recipe)
+	}
+	sort.SliceStable(visible, func(i, j int) bool {
+		return visible[i].Depth < visible[j].Depth
+	})
+	return visible
+}
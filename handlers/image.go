@@ -0,0 +1,233 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mahesh-yadav/go-recipes-api/images"
+	"github.com/mahesh-yadav/go-recipes-api/models"
+	"github.com/rs/zerolog/log"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// multipartOverhead leaves room for the multipart headers around the uploaded file.
+const multipartOverhead = 1 << 20
+
+// UploadRecipeImageHandler godoc
+//
+//	@Summary		Upload a recipe image
+//	@Description	Upload a JPEG, PNG or GIF image for a recipe. The original is stored along with thumbnail, small, medium and large JPEG renditions.
+//	@Tags			recipes
+//	@Accept			multipart/form-data
+//	@Produce		json
+//	@Param			id		path		string	true	"Recipe ID"
+//	@Param			image	formData	file	true	"Image file"
+//	@Success		201		{object}	models.RecipeImage
+//	@Failure		400		{object}	models.ErrorResponse
+//	@Failure		403		{object}	models.ErrorResponse
+//	@Failure		404		{object}	models.ErrorResponse
+//	@Failure		413		{object}	models.ErrorResponse
+//	@Failure		415		{object}	models.ErrorResponse
+//	@Failure		500		{object}	models.ErrorResponse
+//	@Router			/recipes/{id}/images [post]
+func (handler *RecipeHandler) UploadRecipeImageHandler(c *gin.Context) {
+	id := c.Param("id")
+
+	objectID, err := bson.ObjectIDFromHex(id)
+	if err != nil {
+		log.Error().Str("ID", id).Msg("Invalid Recipe ID")
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Invalid Recipe ID",
+		})
+		return
+	}
+
+	recipe, found := handler.findRecipe(objectID)
+	if !found || !recipe.CanView(currentUsername(c)) {
+		c.JSON(http.StatusNotFound, models.ErrorResponse{
+			Code:    http.StatusNotFound,
+			Message: fmt.Sprintf("Recipe not found with ID: %s", id),
+		})
+		return
+	}
+	if !handler.requireEditor(c, recipe) {
+		return
+	}
+
+	maxSize := int64(handler.config.ImageMaxSizeMB) << 20
+	tooLarge := models.ErrorResponse{
+		Code:    http.StatusRequestEntityTooLarge,
+		Message: fmt.Sprintf("Image must not be larger than %d MB", handler.config.ImageMaxSizeMB),
+	}
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxSize+multipartOverhead)
+
+	fileHeader, err := c.FormFile("image")
+	if err != nil {
+		var maxBytesError *http.MaxBytesError
+		if errors.As(err, &maxBytesError) {
+			c.JSON(http.StatusRequestEntityTooLarge, tooLarge)
+			return
+		}
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Code:    http.StatusBadRequest,
+			Message: "An image file is required in the image field",
+		})
+		return
+	}
+	if fileHeader.Size > maxSize {
+		c.JSON(http.StatusRequestEntityTooLarge, tooLarge)
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		log.Panic().Msg("Error opening uploaded image")
+		return
+	}
+	defer file.Close()
+	data, err := io.ReadAll(file)
+	if err != nil {
+		log.Panic().Msg("Error reading uploaded image")
+		return
+	}
+
+	contentType, extension, err := images.DetectContentType(data)
+	if err != nil {
+		c.JSON(http.StatusUnsupportedMediaType, models.ErrorResponse{
+			Code:    http.StatusUnsupportedMediaType,
+			Message: fmt.Sprintf("Unsupported image type %s, expected JPEG, PNG or GIF", contentType),
+		})
+		return
+	}
+
+	renditions, size, err := images.Thumbnails(data)
+	if err != nil {
+		log.Error().Err(err).Str("ID", id).Msg("Invalid image")
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Invalid image",
+		})
+		return
+	}
+
+	image := models.RecipeImage{
+		ID:          bson.NewObjectID().Hex(),
+		ContentType: contentType,
+		Width:       size.X,
+		Height:      size.Y,
+		URLs:        make(map[string]string),
+		Keys:        make([]string, 0, len(renditions)+1),
+		UploadedAt:  time.Now(),
+	}
+	prefix := fmt.Sprintf("recipes/%s/%s", id, image.ID)
+
+	blobs := []images.Rendition{{Name: "original", Data: data}}
+	blobs = append(blobs, renditions...)
+	for _, blob := range blobs {
+		key := fmt.Sprintf("%s/%s.jpg", prefix, blob.Name)
+		blobContentType := "image/jpeg"
+		if blob.Name == "original" {
+			key = fmt.Sprintf("%s/original.%s", prefix, extension)
+			blobContentType = contentType
+		}
+
+		if err := handler.blobStore.Put(handler.ctx, key, blob.Data, blobContentType); err != nil {
+			handler.deleteBlobs(image.Keys)
+			log.Panic().Err(err).Msg("Error storing recipe image")
+			return
+		}
+		image.Keys = append(image.Keys, key)
+		image.URLs[blob.Name] = handler.blobStore.URL(key)
+	}
+
+	filter := bson.D{{Key: "_id", Value: objectID}}
+	updateDoc := bson.D{{Key: "$push", Value: bson.D{{Key: "images", Value: image}}}}
+	if _, err := handler.collection.UpdateOne(handler.ctx, filter, updateDoc); err != nil {
+		handler.deleteBlobs(image.Keys)
+		log.Panic().Msg("Error adding image to recipe in MongoDB")
+		return
+	}
+
+	handler.InvalidateCache()
+	c.JSON(http.StatusCreated, image)
+}
+
+// DeleteRecipeImageHandler godoc
+//
+//	@Summary		Delete a recipe image
+//	@Description	Delete an image and all its renditions from a recipe
+//	@Tags			recipes
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path	string	true	"Recipe ID"
+//	@Param			imageId	path	string	true	"Image ID"
+//	@Success		204
+//	@Failure		400	{object}	models.ErrorResponse
+//	@Failure		403	{object}	models.ErrorResponse
+//	@Failure		404	{object}	models.ErrorResponse
+//	@Failure		500	{object}	models.ErrorResponse
+//	@Router			/recipes/{id}/images/{imageId} [delete]
+func (handler *RecipeHandler) DeleteRecipeImageHandler(c *gin.Context) {
+	id := c.Param("id")
+	imageID := c.Param("imageId")
+
+	objectID, err := bson.ObjectIDFromHex(id)
+	if err != nil {
+		log.Error().Str("ID", id).Msg("Invalid Recipe ID")
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Invalid Recipe ID",
+		})
+		return
+	}
+
+	recipe, found := handler.findRecipe(objectID)
+	var image *models.RecipeImage
+	for i := range recipe.Images {
+		if recipe.Images[i].ID == imageID {
+			image = &recipe.Images[i]
+		}
+	}
+	if !found || image == nil || !recipe.CanView(currentUsername(c)) {
+		c.JSON(http.StatusNotFound, models.ErrorResponse{
+			Code:    http.StatusNotFound,
+			Message: fmt.Sprintf("Image not found with ID: %s", imageID),
+		})
+		return
+	}
+	if !handler.requireEditor(c, recipe) {
+		return
+	}
+
+	filter := bson.D{{Key: "_id", Value: objectID}}
+	updateDoc := bson.D{{Key: "$pull", Value: bson.D{{Key: "images", Value: bson.D{{Key: "id", Value: imageID}}}}}}
+	if _, err := handler.collection.UpdateOne(handler.ctx, filter, updateDoc); err != nil {
+		log.Panic().Msg("Error removing image from recipe in MongoDB")
+		return
+	}
+	handler.deleteBlobs(image.Keys)
+
+	handler.InvalidateCache()
+	c.Status(http.StatusNoContent)
+}
+
+// deleteBlobs removes stored files on a best effort basis: a leftover blob is only
+// wasted space, so failures are logged rather than failing the request.
+func (handler *RecipeHandler) deleteBlobs(keys []string) {
+	for _, key := range keys {
+		if err := handler.blobStore.Delete(handler.ctx, key); err != nil {
+			log.Error().Err(err).Str("key", key).Msg("Error deleting blob")
+		}
+	}
+}
+
+func (handler *RecipeHandler) deleteRecipeImages(recipe models.ViewRecipe) {
+	for _, image := range recipe.Images {
+		handler.deleteBlobs(image.Keys)
+	}
+}
@@ -0,0 +1,198 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"syscall"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/mahesh-yadav/go-recipes-api/config"
+	"github.com/mahesh-yadav/go-recipes-api/models"
+	"github.com/mahesh-yadav/go-recipes-api/schemaorg"
+	"github.com/rs/zerolog/log"
+)
+
+var (
+	errPageTooLarge = errors.New("page too large")
+	errPrivateHost  = errors.New("refusing to fetch from a private address")
+)
+
+// ImportRecipeHandler godoc
+//
+//	@Summary		Import a recipe from a web page
+//	@Description	Extract the schema.org Recipe, from JSON-LD or microdata, of an uploaded HTML document or of the page at url, and save it as a draft. With preview, the parsed recipe is returned without saving.
+//	@Tags			recipes
+//	@Accept			multipart/form-data
+//	@Produce		json
+//	@Param			file	formData	file	false	"HTML document"
+//	@Param			url		formData	string	false	"Page to fetch when no file is uploaded, credited as the source either way"
+//	@Param			preview	query		bool	false	"Return the parsed recipe without saving it"
+//	@Success		200		{object}	models.AddUpdateRecipe
+//	@Success		201
+//	@Failure		400	{object}	models.ErrorResponse
+//	@Failure		413	{object}	models.ErrorResponse
+//	@Failure		422	{object}	models.ErrorResponse
+//	@Failure		502	{object}	models.ErrorResponse
+//	@Failure		500	{object}	models.ErrorResponse
+//	@Router			/recipes/import [post]
+func (handler *RecipeHandler) ImportRecipeHandler(c *gin.Context) {
+	var importParams models.ImportRecipeParams
+	if err := c.ShouldBindQuery(&importParams); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Invalid import parameters",
+		})
+		return
+	}
+
+	maxSize := int64(handler.config.ImportMaxSizeMB) << 20
+	tooLarge := models.ErrorResponse{
+		Code:    http.StatusRequestEntityTooLarge,
+		Message: fmt.Sprintf("Page must not be larger than %d MB", handler.config.ImportMaxSizeMB),
+	}
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxSize+multipartOverhead)
+
+	var page []byte
+	fileHeader, err := c.FormFile("file")
+	pageURL := c.PostForm("url")
+	switch {
+	case err == nil:
+		if fileHeader.Size > maxSize {
+			c.JSON(http.StatusRequestEntityTooLarge, tooLarge)
+			return
+		}
+		file, err := fileHeader.Open()
+		if err != nil {
+			log.Panic().Msg("Error opening uploaded page")
+			return
+		}
+		defer file.Close()
+		if page, err = io.ReadAll(file); err != nil {
+			log.Panic().Msg("Error reading uploaded page")
+			return
+		}
+	case errors.As(err, new(*http.MaxBytesError)):
+		c.JSON(http.StatusRequestEntityTooLarge, tooLarge)
+		return
+	case pageURL != "":
+		page, err = handler.fetchPage(c.Request.Context(), pageURL, maxSize)
+		if errors.Is(err, errPageTooLarge) {
+			c.JSON(http.StatusRequestEntityTooLarge, tooLarge)
+			return
+		}
+		if err != nil {
+			log.Error().Err(err).Str("URL", pageURL).Msg("Error fetching recipe page")
+			c.JSON(http.StatusBadGateway, models.ErrorResponse{
+				Code:    http.StatusBadGateway,
+				Message: fmt.Sprintf("Could not fetch %s", pageURL),
+			})
+			return
+		}
+	default:
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Code:    http.StatusBadRequest,
+			Message: "An HTML file in the file field or a url is required",
+		})
+		return
+	}
+
+	schemaRecipe, err := schemaorg.Extract(bytes.NewReader(page))
+	if err != nil {
+		c.JSON(http.StatusUnprocessableEntity, models.ErrorResponse{
+			Code:    http.StatusUnprocessableEntity,
+			Message: "No schema.org Recipe found in the page",
+		})
+		return
+	}
+	addRecipe := schemaorg.ToRecipe(schemaRecipe, pageURL)
+	if importParams.Preview {
+		c.JSON(http.StatusOK, addRecipe)
+		return
+	}
+
+	if err := binding.Validator.ValidateStruct(&addRecipe); err != nil {
+		c.JSON(http.StatusUnprocessableEntity, models.ErrorResponse{
+			Code:    http.StatusUnprocessableEntity,
+			Message: fmt.Sprintf("Imported recipe is incomplete: %s", err),
+		})
+		return
+	}
+	result, err := handler.insertRecipe(addRecipe, currentUsername(c))
+	if err != nil {
+		c.JSON(http.StatusUnprocessableEntity, models.ErrorResponse{
+			Code:    http.StatusUnprocessableEntity,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, result)
+}
+
+// fetchPage downloads an HTTP or HTTPS page of at most maxSize bytes.
+func (handler *RecipeHandler) fetchPage(ctx context.Context, pageURL string, maxSize int64) ([]byte, error) {
+	parsed, err := url.Parse(pageURL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") {
+		return nil, fmt.Errorf("unsupported URL: %s", pageURL)
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, pageURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Set("Accept", "text/html,application/xhtml+xml")
+	response, err := handler.importClient.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %s", response.Status)
+	}
+
+	page, err := io.ReadAll(io.LimitReader(response.Body, maxSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(page)) > maxSize {
+		return nil, errPageTooLarge
+	}
+	return page, nil
+}
+
+// newImportClient returns the client fetching pages to import. Unless allowed by
+// IMPORT_ALLOW_PRIVATE_HOSTS, it refuses to connect to loopback, private and
+// link-local addresses, so imports cannot reach internal services.
+func newImportClient(config *config.Config) *http.Client {
+	timeout := time.Duration(config.ImportTimeoutSeconds) * time.Second
+	dialer := &net.Dialer{
+		Timeout: timeout,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			if config.ImportAllowPrivateHosts {
+				return nil
+			}
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			ip := net.ParseIP(host)
+			if ip == nil || ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() ||
+				ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() {
+				return errPrivateHost
+			}
+			return nil
+		},
+	}
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: &http.Transport{DialContext: dialer.DialContext},
+	}
+}
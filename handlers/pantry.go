@@ -0,0 +1,203 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mahesh-yadav/go-recipes-api/ingredients"
+	"github.com/mahesh-yadav/go-recipes-api/models"
+	"github.com/rs/zerolog/log"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+const defaultMatchLimit = 20
+
+type PantryHandler struct {
+	ctx              context.Context
+	collection       *mongo.Collection
+	recipeCollection *mongo.Collection
+}
+
+func NewPantryHandler(ctx context.Context, collection *mongo.Collection, recipeCollection *mongo.Collection) *PantryHandler {
+	return &PantryHandler{
+		ctx:              ctx,
+		collection:       collection,
+		recipeCollection: recipeCollection,
+	}
+}
+
+// GetPantryHandler godoc
+//
+//	@Summary		Get the pantry
+//	@Description	Get the list of ingredients the signed in user has at hand
+//	@Tags			pantry
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header		string	true	"{token}"
+//	@Success		200				{object}	models.Pantry
+//	@Failure		401				{object}	models.ErrorResponse
+//	@Failure		500				{object}	models.ErrorResponse
+//	@Router			/pantry [get]
+func (handler *PantryHandler) GetPantryHandler(c *gin.Context) {
+	pantry := handler.findPantry(currentUsername(c))
+	c.JSON(http.StatusOK, pantry)
+}
+
+// UpdatePantryHandler godoc
+//
+//	@Summary		Update the pantry
+//	@Description	Replace the list of ingredients the signed in user has at hand
+//	@Tags			pantry
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header		string				true	"{token}"
+//	@Param			pantry			body		models.UpdatePantry	true	"Pantry items"
+//	@Success		200				{object}	models.Pantry
+//	@Failure		400				{object}	models.ErrorResponse
+//	@Failure		401				{object}	models.ErrorResponse
+//	@Failure		500				{object}	models.ErrorResponse
+//	@Router			/pantry [put]
+func (handler *PantryHandler) UpdatePantryHandler(c *gin.Context) {
+	var updatePantry models.UpdatePantry
+	if err := c.ShouldBindJSON(&updatePantry); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Invalid Pantry data",
+		})
+		return
+	}
+
+	pantry := models.Pantry{
+		Username:  currentUsername(c),
+		Items:     cleanPantryItems(updatePantry.Items),
+		UpdatedAt: time.Now(),
+	}
+
+	filter := bson.D{{Key: "username", Value: pantry.Username}}
+	_, err := handler.collection.ReplaceOne(handler.ctx, filter, pantry, options.Replace().SetUpsert(true))
+	if err != nil {
+		log.Panic().Msg("Error saving pantry in MongoDB")
+		return
+	}
+
+	c.JSON(http.StatusOK, pantry)
+}
+
+// MatchRecipesHandler godoc
+//
+//	@Summary		What can I cook?
+//	@Description	Rank recipes by how many of their ingredients are covered by the given items, or by the signed in user's pantry when no items are given
+//	@Tags			pantry
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header		string	true	"{token}"
+//	@Param			have			query		string	false	"Comma separated ingredients at hand, e.g. egg,flour,milk"
+//	@Param			limit			query		int		false	"Maximum number of recipes to return"
+//	@Success		200				{object}	models.ListRecipeMatches
+//	@Failure		400				{object}	models.ErrorResponse
+//	@Failure		401				{object}	models.ErrorResponse
+//	@Failure		500				{object}	models.ErrorResponse
+//	@Router			/recipes/match [get]
+func (handler *PantryHandler) MatchRecipesHandler(c *gin.Context) {
+	var matchParams models.PantryMatchParams
+	if err := c.ShouldBindQuery(&matchParams); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Invalid match parameters",
+		})
+		return
+	}
+	if matchParams.Limit == 0 {
+		matchParams.Limit = defaultMatchLimit
+	}
+
+	var items []string
+	if matchParams.Have != "" {
+		items = cleanPantryItems(strings.Split(matchParams.Have, ","))
+	} else {
+		items = handler.findPantry(currentUsername(c)).Items
+	}
+	if len(items) == 0 {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Pantry is empty, add items or pass them with the have parameter",
+		})
+		return
+	}
+
+	cursor, err := handler.recipeCollection.Find(handler.ctx, bson.D{publishedFilter(), listedFilter(), notDeletedFilter()})
+	if err != nil {
+		log.Panic().Msg("Error fetching recipes from MongoDB")
+		return
+	}
+	defer cursor.Close(handler.ctx)
+
+	matches := make([]models.RecipeMatch, 0)
+	for cursor.Next(handler.ctx) {
+		var recipe models.ViewRecipe
+		if err := cursor.Decode(&recipe); err != nil {
+			log.Panic().Msg("Error decoding recipe from MongoDB")
+			return
+		}
+
+		coverage := ingredients.Match(items, recipe.Ingredients)
+		if coverage.Matched == 0 {
+			continue
+		}
+		recipe.HideCollaborators(currentUsername(c))
+		matches = append(matches, models.RecipeMatch{
+			Recipe:   recipe,
+			Matched:  coverage.Matched,
+			Total:    coverage.Total,
+			Coverage: coverage.Ratio(),
+			Missing:  coverage.Missing,
+		})
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].Coverage != matches[j].Coverage {
+			return matches[i].Coverage > matches[j].Coverage
+		}
+		return len(matches[i].Missing) < len(matches[j].Missing)
+	})
+	if len(matches) > matchParams.Limit {
+		matches = matches[:matchParams.Limit]
+	}
+
+	c.JSON(http.StatusOK, models.ListRecipeMatches{
+		Count: len(matches),
+		Data:  matches,
+	})
+}
+
+func (handler *PantryHandler) findPantry(username string) models.Pantry {
+	pantry := models.Pantry{Username: username, Items: make([]string, 0)}
+
+	filter := bson.D{{Key: "username", Value: username}}
+	err := handler.collection.FindOne(handler.ctx, filter).Decode(&pantry)
+	if err != nil && err != mongo.ErrNoDocuments {
+		log.Panic().Msg("Error fetching pantry from MongoDB")
+	}
+	return pantry
+}
+
+func cleanPantryItems(items []string) []string {
+	seen := make(map[string]bool)
+	cleaned := make([]string, 0, len(items))
+	for _, item := range items {
+		item = strings.ToLower(strings.TrimSpace(item))
+		normalized := ingredients.Normalize(item)
+		if normalized == "" || seen[normalized] {
+			continue
+		}
+		seen[normalized] = true
+		cleaned = append(cleaned, item)
+	}
+	return cleaned
+}
@@ -0,0 +1,95 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mahesh-yadav/go-recipes-api/export"
+	"github.com/mahesh-yadav/go-recipes-api/models"
+	"github.com/mahesh-yadav/go-recipes-api/storage"
+	"github.com/rs/zerolog/log"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+const pdfContentType = "application/pdf"
+
+// pdfRenditions are the image sizes tried for print, in order of preference.
+var pdfRenditions = []string{"medium.jpg", "large.jpg", "small.jpg"}
+
+// GetRecipePDFHandler godoc
+//
+//	@Summary		Print a recipe
+//	@Description	Render a recipe as a printable PDF card with its image, ingredients in two columns and numbered steps
+//	@Tags			recipes
+//	@Accept			json
+//	@Produce		application/pdf
+//	@Param			id				path		string	true	"Recipe ID"
+//	@Param			Accept-Language	header		string	false	"Preferred locales, e.g. es, en;q=0.8"
+//	@Success		200				{file}		file
+//	@Failure		400				{object}	models.ErrorResponse
+//	@Failure		404				{object}	models.ErrorResponse
+//	@Failure		500				{object}	models.ErrorResponse
+//	@Router			/recipes/{id}.pdf [get]
+func (handler *RecipeHandler) GetRecipePDFHandler(c *gin.Context) {
+	id := strings.TrimSuffix(c.Param("id"), ".pdf")
+
+	objectID, err := bson.ObjectIDFromHex(id)
+	if err != nil {
+		log.Error().Str("ID", id).Msg("Invalid Recipe ID")
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Invalid Recipe ID",
+		})
+		return
+	}
+
+	recipe, found := handler.findRecipe(objectID)
+	if !found || !recipe.CanView(currentUsername(c)) {
+		c.JSON(http.StatusNotFound, models.ErrorResponse{
+			Code:    http.StatusNotFound,
+			Message: fmt.Sprintf("Recipe not found with ID: %s", id),
+		})
+		return
+	}
+	localizeRecipe(c, &recipe)
+
+	printed := export.PrintedRecipe{Recipe: recipe, Image: printImage(handler.ctx, handler.blobStore, recipe)}
+	var body bytes.Buffer
+	if err := export.RecipePDF(&body, printed); err != nil {
+		log.Panic().Err(err).Str("ID", id).Msg("Error rendering recipe PDF")
+		return
+	}
+	writePDF(c, id, body.Bytes())
+}
+
+// printImage loads the recipe's first image for print. A recipe is still worth
+// printing without its picture, so failures are logged and nil returned.
+func printImage(ctx context.Context, blobStore storage.BlobStore, recipe models.ViewRecipe) *export.Image {
+	if len(recipe.Images) == 0 {
+		return nil
+	}
+	keys := recipe.Images[0].Keys
+	for _, rendition := range pdfRenditions {
+		for _, key := range keys {
+			if !strings.HasSuffix(key, "/"+rendition) {
+				continue
+			}
+			data, err := blobStore.Get(ctx, key)
+			if err != nil {
+				log.Error().Err(err).Str("key", key).Msg("Error loading image for PDF")
+				return nil
+			}
+			return &export.Image{Data: data, Type: "JPG"}
+		}
+	}
+	return nil
+}
+
+func writePDF(c *gin.Context, name string, body []byte) {
+	c.Header("Content-Disposition", fmt.Sprintf("inline; filename=%q", name+".pdf"))
+	c.Data(http.StatusOK, pdfContentType, body)
+}
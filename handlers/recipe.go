@@ -3,56 +3,140 @@ package handlers
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/rs/zerolog/log"
 
 	"github.com/gin-gonic/gin"
 	"github.com/mahesh-yadav/go-recipes-api/config"
+	"github.com/mahesh-yadav/go-recipes-api/dietary"
+	"github.com/mahesh-yadav/go-recipes-api/ingredients"
+	"github.com/mahesh-yadav/go-recipes-api/isoduration"
+	"github.com/mahesh-yadav/go-recipes-api/locale"
 	"github.com/mahesh-yadav/go-recipes-api/models"
+	"github.com/mahesh-yadav/go-recipes-api/nutrition"
+	"github.com/mahesh-yadav/go-recipes-api/query"
+	"github.com/mahesh-yadav/go-recipes-api/search"
+	"github.com/mahesh-yadav/go-recipes-api/semantic"
+	"github.com/mahesh-yadav/go-recipes-api/similarity"
+	"github.com/mahesh-yadav/go-recipes-api/steps"
+	"github.com/mahesh-yadav/go-recipes-api/storage"
+	"github.com/mahesh-yadav/go-recipes-api/taxonomy"
 	"github.com/redis/go-redis/v9"
 	"go.mongodb.org/mongo-driver/v2/bson"
 	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+var difficulties = []string{"easy", "medium", "hard"}
+
+// sparseSearchResults is the number of results below which a spelling correction is offered.
+const sparseSearchResults = 3
+
+const (
+	recipesCacheKey        = "recipes"
+	similarRecipesCacheKey = "recipes:similar"
+	maxSimilarRecipes      = 50
+	defaultSimilarRecipes  = 10
+	taxonomyCacheTTL       = time.Minute
 )
 
 type RecipeHandler struct {
-	collection  *mongo.Collection
-	ctx         context.Context
-	redisClient *redis.Client
-	config      *config.Config
+	collection        *mongo.Collection
+	versionCollection *mongo.Collection
+	tagCollection     *mongo.Collection
+	ctx               context.Context
+	redisClient       *redis.Client
+	config            *config.Config
+	blobStore         storage.BlobStore
+	suggester         *search.Suggester
+	searchIndex       search.SearchIndex
+	embedder          semantic.Embedder
+	semanticIndex     *semantic.Index
+	importClient      *http.Client
+	tagsMutex         sync.Mutex
+	tags              *taxonomy.Taxonomy
+	tagsLoadedAt      time.Time
 }
 
-func NewRecipeHandler(ctx context.Context, collection *mongo.Collection, redisClient *redis.Client, config *config.Config) *RecipeHandler {
+func NewRecipeHandler(ctx context.Context, collection *mongo.Collection, versionCollection *mongo.Collection, tagCollection *mongo.Collection, redisClient *redis.Client, config *config.Config, blobStore storage.BlobStore, searchIndex search.SearchIndex, embedder semantic.Embedder) *RecipeHandler {
 	return &RecipeHandler{
-		collection:  collection,
-		ctx:         ctx,
-		redisClient: redisClient,
-		config:      config,
+		collection:        collection,
+		versionCollection: versionCollection,
+		tagCollection:     tagCollection,
+		ctx:               ctx,
+		redisClient:       redisClient,
+		config:            config,
+		blobStore:         blobStore,
+		suggester:         search.NewSuggester(),
+		searchIndex:       searchIndex,
+		embedder:          embedder,
+		semanticIndex:     semantic.NewIndex(embedder.Dimensions()),
+		importClient:      newImportClient(config),
 	}
 }
 
 // ListRecipesHandler godoc
 //
 //	@Summary		List all recipes
-//	@Description	Get a list of all recipes
+//	@Description	Get a list of all published recipes
 //	@Tags			recipes
 //	@Accept			json
 //	@Produce		json
-//	@Success		200	{object}	models.ListRecipes
-//	@Failure		500	{object}	models.ErrorResponse
+//	@Param			exclude_allergens	query		string	false	"Comma separated allergens to exclude, e.g. nuts,dairy"
+//	@Param			diet				query		string	false	"Comma separated dietary labels to require, e.g. vegan"
+//	@Param			max_total_time		query		string	false	"Maximum total time, e.g. 30m or PT30M"
+//	@Param			cuisine				query		string	false	"Comma separated cuisines, any of which match, e.g. italian,mexican"
+//	@Param			course				query		string	false	"Comma separated courses, any of which match, e.g. dessert"
+//	@Param			difficulty			query		string	false	"Comma separated difficulties, any of which match, e.g. easy,medium"
+//	@Param			Accept-Language		header		string	false	"Preferred locales, e.g. es, en;q=0.8"
+//	@Success		200					{object}	models.ListRecipes
+//	@Failure		400					{object}	models.ErrorResponse
+//	@Failure		500					{object}	models.ErrorResponse
 //	@Router			/recipes [get]
 func (handler *RecipeHandler) ListRecipesHandler(c *gin.Context) {
+	var filterParams models.RecipeFilterParams
+	if err := c.ShouldBindQuery(&filterParams); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Invalid Recipe filter parameters",
+		})
+		return
+	}
+	filter, err := recipeFilter(filterParams)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Code:    http.StatusBadRequest,
+			Message: err.Error(),
+		})
+		return
+	}
+	if len(filter) > 0 {
+		recipes := handler.findRecipes(append(filter, publishedFilter(), listedFilter(), notDeletedFilter()))
+		localizeRecipes(c, recipes)
+		hideCollaborators(c, recipes)
+		c.JSON(http.StatusOK, models.ListRecipes{
+			Count: len(recipes),
+			Data:  recipes,
+		})
+		return
+	}
+
 	var redisResults string
-	var err error = nil
 	if handler.config.EnableRedisCache {
-		redisResults, err = handler.redisClient.Get(handler.ctx, "recipes").Result()
+		redisResults, err = handler.redisClient.Get(handler.ctx, recipesCacheKey).Result()
 	}
 	if !config.GetConfig().EnableRedisCache || err == redis.Nil {
 		log.Info().Msg("Fetching from MongoDB...")
 
-		cursor, err := handler.collection.Find(context.TODO(), bson.D{})
+		cursor, err := handler.collection.Find(context.TODO(), bson.D{publishedFilter(), listedFilter(), notDeletedFilter()})
 		if err != nil {
 			log.Panic().Msg("Error fetching recipes from MongoDB")
 		}
@@ -72,9 +156,11 @@ func (handler *RecipeHandler) ListRecipesHandler(c *gin.Context) {
 			if err != nil {
 				log.Panic().Msg("Error marshalling recipies to JSON")
 			}
-			handler.redisClient.Set(handler.ctx, "recipes", string(data), 0)
+			handler.redisClient.Set(handler.ctx, recipesCacheKey, string(data), 0)
 		}
 
+		localizeRecipes(c, recipes)
+		hideCollaborators(c, recipes)
 		c.JSON(http.StatusOK, models.ListRecipes{
 			Count: len(recipes),
 			Data:  recipes,
@@ -88,6 +174,8 @@ func (handler *RecipeHandler) ListRecipesHandler(c *gin.Context) {
 		if err != nil {
 			log.Panic().Msg("Error unmarshalling recipies from Redis cache to JSON")
 		}
+		localizeRecipes(c, recipes)
+		hideCollaborators(c, recipes)
 
 		c.JSON(http.StatusOK, models.ListRecipes{
 			Count: len(recipes),
@@ -99,18 +187,24 @@ func (handler *RecipeHandler) ListRecipesHandler(c *gin.Context) {
 // GetRecipeHandler godoc
 //
 //	@Summary		Get a recipe by ID
-//	@Description	Get details of a specific recipe by its ID
+//	@Description	Get details of a specific recipe by its ID. Unpublished and private recipes are only visible to their author, unlisted recipes to anyone with their ID. The recipe can also be exported as schema.org JSON-LD, Markdown or plain text, chosen with format or the Accept header, or printed from /recipes/{id}.pdf.
 //	@Tags			recipes
 //	@Accept			json
-//	@Produce		json
-//	@Param			id	path		string	true	"Recipe ID"
-//	@Success		200	{object}	models.ViewRecipe
-//	@Failure		400	{object}	models.ErrorResponse
-//	@Failure		404	{object}	models.ErrorResponse
-//	@Failure		500	{object}	models.ErrorResponse
+//	@Produce		json,application/ld+json,text/markdown,plain
+//	@Param			id				path		string	true	"Recipe ID"
+//	@Param			format			query		string	false	"Export format, e.g. jsonld, markdown or text"
+//	@Param			Accept-Language	header		string	false	"Preferred locales, e.g. es, en;q=0.8"
+//	@Success		200				{object}	models.ViewRecipe
+//	@Failure		400				{object}	models.ErrorResponse
+//	@Failure		404				{object}	models.ErrorResponse
+//	@Failure		500				{object}	models.ErrorResponse
 //	@Router			/recipes/{id} [get]
 func (handler *RecipeHandler) GetRecipeHandler(c *gin.Context) {
 	id := c.Param("id")
+	if strings.HasSuffix(id, ".pdf") {
+		handler.GetRecipePDFHandler(c)
+		return
+	}
 
 	objectID, err := bson.ObjectIDFromHex(id)
 	if err != nil {
@@ -122,7 +216,7 @@ func (handler *RecipeHandler) GetRecipeHandler(c *gin.Context) {
 		return
 	}
 
-	filter := bson.D{{Key: "_id", Value: objectID}}
+	filter := bson.D{{Key: "_id", Value: objectID}, notDeletedFilter()}
 
 	var recipe models.ViewRecipe
 	err = handler.collection.FindOne(handler.ctx, filter).Decode(&recipe)
@@ -138,13 +232,23 @@ func (handler *RecipeHandler) GetRecipeHandler(c *gin.Context) {
 		log.Panic().Msg("Error fetching recipe from MongoDB")
 	}
 
-	c.JSON(http.StatusOK, recipe)
+	if !recipe.CanView(currentUsername(c)) {
+		c.JSON(http.StatusNotFound, models.ErrorResponse{
+			Code:    http.StatusNotFound,
+			Message: fmt.Sprintf("Recipe not found with ID: %s", id),
+		})
+		return
+	}
+
+	localizeRecipe(c, &recipe)
+	recipe.HideCollaborators(currentUsername(c))
+	writeRecipe(c, recipe)
 }
 
 // CreateRecipeHandler godoc
 //
 //	@Summary		Create a new recipe
-//	@Description	Create a new recipe
+//	@Description	Create a new recipe as a draft. Change its status to publish it.
 //	@Tags			recipes
 //	@Accept			json
 //	@Produce		json
@@ -163,30 +267,40 @@ func (handler *RecipeHandler) CreateRecipeHandler(c *gin.Context) {
 		return
 	}
 
-	recipe := models.Recipe{
-		Name:         addRecipe.Name,
-		Tags:         addRecipe.Tags,
-		Ingredients:  addRecipe.Ingredients,
-		Instructions: addRecipe.Instructions,
+	result, err := handler.insertRecipe(addRecipe, currentUsername(c))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Code:    http.StatusBadRequest,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, result)
+}
+
+// insertRecipe saves new recipe input as a draft by author. It only fails when the
+// steps reference missing ingredients.
+func (handler *RecipeHandler) insertRecipe(addRecipe models.AddUpdateRecipe, author string) (*mongo.InsertOneResult, error) {
+	recipe, err := handler.newDraft(addRecipe, author)
+	if err != nil {
+		return nil, err
 	}
-	recipe.PublishedAt = time.Now()
 
 	result, err := handler.collection.InsertOne(handler.ctx, recipe)
 	if err != nil {
 		log.Panic().Msg("Error inserting recipe into MongoDB")
 	}
 
-	if handler.config.EnableRedisCache {
-		log.Info().Msg("Removing recipes from Redis cache...")
-		handler.redisClient.Del(handler.ctx, "recipes")
-	}
-	c.JSON(http.StatusCreated, result)
+	handler.InvalidateCache()
+	handler.indexRecipe(result.InsertedID.(bson.ObjectID))
+	return result, nil
 }
 
 // UpdateRecipeHandler godoc
 //
 //	@Summary		Update a recipe
-//	@Description	Update a recipe
+//	@Description	Update a recipe. The previous content is kept in the recipe's version history. Visibility and default locale are kept when left out. Only the author and editors can update a recipe, and only admins can update recipes without an author.
 //	@Tags			recipes
 //	@Accept			json
 //	@Produce		json
@@ -194,6 +308,9 @@ func (handler *RecipeHandler) CreateRecipeHandler(c *gin.Context) {
 //	@Param			recipe	body	models.AddUpdateRecipe	true	"Update Recipe"
 //	@Success		200
 //	@Failure		400	{object}	models.ErrorResponse
+//	@Failure		403	{object}	models.ErrorResponse
+//	@Failure		404	{object}	models.ErrorResponse
+//	@Failure		409	{object}	models.ErrorResponse
 //	@Failure		500	{object}	models.ErrorResponse
 //	@Router			/recipes/{id} [put]
 func (handler *RecipeHandler) UpdateRecipeHandler(c *gin.Context) {
@@ -218,80 +335,126 @@ func (handler *RecipeHandler) UpdateRecipeHandler(c *gin.Context) {
 		return
 	}
 
-	filter := bson.D{{Key: "_id", Value: objectID}}
-	updateDoc := bson.D{{
-		Key: "$set",
-		Value: bson.D{
-			{Key: "name", Value: updateRecipe.Name},
-			{Key: "tags", Value: updateRecipe.Tags},
-			{Key: "ingredients", Value: updateRecipe.Ingredients},
-			{Key: "instructions", Value: updateRecipe.Instructions},
-			{Key: "published_at", Value: time.Now()},
-		}}}
-
-	result, err := handler.collection.UpdateOne(handler.ctx, filter, updateDoc)
-	if err != nil {
-		log.Panic().Msg("Error updating recipe in MongoDB")
+	existing, found := handler.findRecipe(objectID)
+	if !found || !existing.CanView(currentUsername(c)) {
+		c.JSON(http.StatusNotFound, models.ErrorResponse{
+			Code:    http.StatusNotFound,
+			Message: fmt.Sprintf("Recipe not found with ID: %s", id),
+		})
+		return
+	}
+	if !handler.requireEditor(c, existing) {
 		return
 	}
 
-	if handler.config.EnableRedisCache {
-		log.Info().Msg("Removing recipes from Redis cache...")
-		handler.redisClient.Del(handler.ctx, "recipes")
+	recipe := newRecipe(updateRecipe)
+	recipe.Tags = handler.taxonomy().CanonicalAll(recipe.Tags)
+	if err := validateSteps(recipe.Instructions, len(recipe.Ingredients), existing.Images); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Code:    http.StatusBadRequest,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	fields := append(recipeContentFields(recipe), recipeSettingFields(updateRecipe)...)
+	if !existing.LabelsOverridden {
+		labels := dietary.Detect(updateRecipe.Ingredients)
+		fields = append(fields,
+			bson.E{Key: "allergens", Value: labels.Allergens},
+			bson.E{Key: "diets", Value: labels.Diets},
+		)
 	}
+
+	result, updated := handler.updateRecipeVersioned(existing, fields, currentUsername(c))
+	if !updated {
+		recipeConflict(c)
+		return
+	}
+
+	handler.InvalidateCache()
+	handler.indexRecipe(objectID)
 	c.JSON(http.StatusOK, result)
 }
 
 // DeleteRecipeHandler godoc
 //
 //	@Summary		Delete a recipe
-//	@Description	Delete a recipe
+//	@Description	Move a recipe to the trash. Trashed recipes can be restored until they are purged after the retention period. Only the author or an admin can delete a recipe.
 //	@Tags			recipes
 //	@Accept			json
 //	@Produce		json
 //	@Param			id	path	string	true	"Recipe ID"
 //	@Success		204
 //	@Failure		400	{object}	models.ErrorResponse
+//	@Failure		403	{object}	models.ErrorResponse
+//	@Failure		404	{object}	models.ErrorResponse
 //	@Failure		500	{object}	models.ErrorResponse
 //	@Router			/recipes/{id} [delete]
 func (handler *RecipeHandler) DeleteRecipeHandler(c *gin.Context) {
-	id := c.Param("id")
-	objectID, err := bson.ObjectIDFromHex(id)
-	if err != nil {
-		log.Error().Str("ID", id).Msg("Invalid Recipe ID")
-		c.JSON(http.StatusBadRequest, models.ErrorResponse{
-			Code:    http.StatusBadRequest,
-			Message: "Invalid Recipe ID",
-		})
+	recipe, ok := handler.bindRecipe(c)
+	if !ok {
 		return
 	}
 
-	filter := bson.D{{Key: "_id", Value: objectID}}
+	username := currentUsername(c)
+	if (recipe.Author == "" || recipe.Author != username) && !isAdmin(handler.config, username) {
+		c.JSON(http.StatusForbidden, models.ErrorResponse{
+			Code:    http.StatusForbidden,
+			Message: "Only the author can delete this recipe, editors can only change it",
+		})
+		return
+	}
 
-	result, err := handler.collection.DeleteOne(handler.ctx, filter)
+	filter := bson.D{{Key: "_id", Value: recipe.ID}, notDeletedFilter()}
+	update := bson.D{{Key: "$set", Value: bson.D{
+		{Key: "deleted_at", Value: time.Now()},
+		{Key: "deleted_by", Value: username},
+	}}}
+	result, err := handler.collection.UpdateOne(handler.ctx, filter, update)
 	if err != nil {
 		log.Panic().Msg("Error deleting recipe in MongoDB")
 		return
 	}
-
-	if handler.config.EnableRedisCache {
-		log.Info().Msg("Removing recipes from Redis cache...")
-		handler.redisClient.Del(handler.ctx, "recipes")
+	if result.MatchedCount == 0 {
+		c.JSON(http.StatusNotFound, models.ErrorResponse{
+			Code:    http.StatusNotFound,
+			Message: fmt.Sprintf("Recipe not found with ID: %s", recipe.ID.Hex()),
+		})
+		return
 	}
-	c.JSON(http.StatusOK, result)
+
+	handler.countFork(recipe.ForkedFrom, -1)
+	handler.InvalidateCache()
+	handler.indexRecipe(recipe.ID)
+	c.Status(http.StatusNoContent)
 }
 
 // SearchRecipeHandler godoc
 //
-//	@Summary		Search recipes by tag
-//	@Description	Search recipes by tag
+//	@Summary		Search recipes
+//	@Description	Search recipes by text and multi-select filters and get the matching page along with facet counts per tag, cuisine, difficulty and total time. When few recipes match a text query, did_you_mean offers a spelling correction. Values within a filter are combined with OR, filters with AND. Tag synonyms resolve to their tag and child tags are included, so poultry also finds chicken.
 //	@Tags			recipes
 //	@Accept			json
 //	@Produce		json
-//	@Param			tag	query		string	true	"Tag to search for"
-//	@Success		200	{object}	models.ListRecipes
-//	@Failure		400	{object}	models.ErrorResponse
-//	@Failure		500	{object}	models.ErrorResponse
+//	@Param			q					query		string	false	"Words that must all appear in the name or ingredients, at most 200 characters, e.g. chicken garlic"
+//	@Param			query				query		string	false	"Search expression, e.g. tag:dessert AND (ingredient:chocolate OR ingredient:cocoa) -nuts time<30m"
+//	@Param			fuzzy				query		bool	false	"Also match words within one or two typos of the first eight query words"
+//	@Param			tag					query		string	false	"Comma separated tags, any of which match, e.g. chicken,fish"
+//	@Param			have				query		string	false	"Comma separated ingredients at hand, at least one of which must be used, e.g. egg,flour,milk"
+//	@Param			total_time			query		string	false	"Comma separated total time buckets, any of which match"	Enums(under_15m, 15_30m, 30_60m, over_60m)
+//	@Param			exclude_allergens	query		string	false	"Comma separated allergens to exclude, e.g. nuts,dairy"
+//	@Param			diet				query		string	false	"Comma separated dietary labels to require, e.g. vegan"
+//	@Param			max_total_time		query		string	false	"Maximum total time, e.g. 30m or PT30M"
+//	@Param			cuisine				query		string	false	"Comma separated cuisines, any of which match, e.g. italian,mexican"
+//	@Param			course				query		string	false	"Comma separated courses, any of which match, e.g. dessert"
+//	@Param			difficulty			query		string	false	"Comma separated difficulties, any of which match, e.g. easy,medium"
+//	@Param			page				query		int		false	"Page number, starting at 1"
+//	@Param			page_size			query		int		false	"Recipes per page, at most 100"
+//	@Param			Accept-Language		header		string	false	"Preferred locales, e.g. es, en;q=0.8"
+//	@Success		200					{object}	models.RecipeSearchResult
+//	@Failure		400					{object}	models.ErrorResponse
+//	@Failure		500					{object}	models.ErrorResponse
 //	@Router			/recipes/search [get]
 func (handler *RecipeHandler) SearchRecipeHandler(c *gin.Context) {
 	var searchParams models.RecipeTagSearchParams
@@ -303,14 +466,586 @@ func (handler *RecipeHandler) SearchRecipeHandler(c *gin.Context) {
 		})
 		return
 	}
+	if searchParams.Page == 0 {
+		searchParams.Page = 1
+	}
+	if searchParams.PageSize == 0 {
+		searchParams.PageSize = defaultSearchPageSize
+	}
+
+	// Cuisine and difficulty are facets, applied by the facet pipeline.
+	filterParams := searchParams.RecipeFilterParams
+	filterParams.Cuisine, filterParams.Difficulty = "", ""
+	base, err := recipeFilter(filterParams)
+	if err == nil {
+		err = validateDifficulties(splitList(searchParams.Difficulty))
+	}
+	var totalTime []string
+	if err == nil {
+		totalTime, err = parseTimeBuckets(searchParams.TotalTime)
+	}
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Code:    http.StatusBadRequest,
+			Message: err.Error(),
+		})
+		return
+	}
+	base = append(base, publishedFilter(), listedFilter(), notDeletedFilter())
+	tags := handler.taxonomy()
+	if searchParams.Expression != "" {
+		expression, err := query.Compile(searchParams.Expression, query.Options{ExpandTag: tags.Expand})
+		var syntaxError *query.SyntaxError
+		if errors.As(err, &syntaxError) {
+			c.JSON(http.StatusBadRequest, models.ErrorResponse{
+				Code:    http.StatusBadRequest,
+				Message: "Invalid query: " + syntaxError.Error(),
+				Token:   syntaxError.Token,
+				Column:  syntaxError.Column,
+			})
+			return
+		}
+		base = append(base, bson.E{Key: "$and", Value: bson.A{expression}})
+	}
+	if words := search.Words(searchParams.Query); len(words) > 0 {
+		textQuery := search.Query{Words: make([][]string, 0, len(words))}
+		for i, word := range words {
+			if searchParams.Fuzzy && i < search.MaxFuzzyWords {
+				textQuery.Words = append(textQuery.Words, handler.suggester.Expand(word))
+			} else {
+				textQuery.Words = append(textQuery.Words, []string{word})
+			}
+		}
+		ids, err := handler.searchIndex.Search(handler.ctx, textQuery)
+		if err != nil {
+			log.Panic().Err(err).Msg("Error searching the recipe index")
+			return
+		}
+		base = append(base, idsFilter(ids))
+	}
+	if searchParams.Have != "" {
+		base = append(base, handler.haveFilter(base, cleanPantryItems(strings.Split(searchParams.Have, ","))))
+	}
 
-	filter := bson.D{{Key: "tags", Value: bson.D{{Key: "$in", Value: []string{searchParams.Tag}}}}}
+	selectedTags := tags.CanonicalAll(splitList(searchParams.Tag))
+	filters := facetFilters{
+		cuisine:    splitList(searchParams.Cuisine),
+		difficulty: splitList(searchParams.Difficulty),
+		totalTime:  totalTime,
+	}
+	for _, tag := range selectedTags {
+		filters.tags = append(filters.tags, tags.Expand(tag)...)
+	}
 
-	cursor, err := handler.collection.Find(handler.ctx, filter)
+	cursor, err := handler.collection.Aggregate(handler.ctx, facetPipeline(base, filters, searchParams.Page, searchParams.PageSize))
 	if err != nil {
 		log.Panic().Msg("Error searching recipes in MongoDB")
 		return
 	}
+	var results []struct {
+		Results []models.ViewRecipe `bson:"results"`
+		Total   []struct {
+			Count int `bson:"count"`
+		} `bson:"total"`
+		Tags       []models.FacetValue `bson:"tags"`
+		Cuisine    []models.FacetValue `bson:"cuisine"`
+		Difficulty []models.FacetValue `bson:"difficulty"`
+		TotalTime  []models.FacetValue `bson:"total_time"`
+	}
+	if err := cursor.All(handler.ctx, &results); err != nil || len(results) == 0 {
+		log.Panic().Msg("Error decoding recipe search results from MongoDB")
+		return
+	}
+
+	result := results[0]
+	if result.Results == nil {
+		result.Results = make([]models.ViewRecipe, 0)
+	}
+	total := 0
+	if len(result.Total) > 0 {
+		total = result.Total[0].Count
+	}
+	localizeRecipes(c, result.Results)
+	hideCollaborators(c, result.Results)
+
+	didYouMean := ""
+	if total < sparseSearchResults && searchParams.Query != "" {
+		if corrected, ok := handler.suggester.Correct(searchParams.Query); ok {
+			didYouMean = corrected
+		}
+	}
+
+	c.JSON(http.StatusOK, models.RecipeSearchResult{
+		Count:    len(result.Results),
+		Total:    total,
+		Page:     searchParams.Page,
+		PageSize: searchParams.PageSize,
+		Data:     result.Results,
+		Facets: models.RecipeFacets{
+			Tags:       markSelected(result.Tags, selectedTags),
+			Cuisine:    markSelected(result.Cuisine, filters.cuisine),
+			Difficulty: markSelected(result.Difficulty, filters.difficulty),
+			TotalTime:  sortTimeBuckets(markSelected(result.TotalTime, filters.totalTime)),
+		},
+		DidYouMean: didYouMean,
+	})
+}
+
+// SimilarRecipesHandler godoc
+//
+//	@Summary		List similar recipes
+//	@Description	Get recipes similar to the given one, scored by ingredient and tag overlap. Only recipes the user can view are compared or returned.
+//	@Tags			recipes
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string	true	"Recipe ID"
+//	@Param			limit	query		int		false	"Maximum number of recipes to return"
+//	@Success		200		{object}	models.ListSimilarRecipes
+//	@Failure		400		{object}	models.ErrorResponse
+//	@Failure		404		{object}	models.ErrorResponse
+//	@Failure		500		{object}	models.ErrorResponse
+//	@Router			/recipes/{id}/similar [get]
+func (handler *RecipeHandler) SimilarRecipesHandler(c *gin.Context) {
+	recipe, ok := handler.bindRecipe(c)
+	if !ok {
+		return
+	}
+	id := recipe.ID.Hex()
+
+	var similarParams models.SimilarRecipeParams
+	if err := c.ShouldBindQuery(&similarParams); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Invalid similar recipe parameters",
+		})
+		return
+	}
+	if similarParams.Limit == 0 {
+		similarParams.Limit = defaultSimilarRecipes
+	}
+
+	similar, found := handler.cachedSimilarRecipes(id)
+	if !found {
+		similar, found = handler.computeSimilarRecipes(id)
+		if !found {
+			c.JSON(http.StatusNotFound, models.ErrorResponse{
+				Code:    http.StatusNotFound,
+				Message: fmt.Sprintf("Recipe not found with ID: %s", id),
+			})
+			return
+		}
+		handler.cacheSimilarRecipes(id, similar)
+	}
+
+	// Cached lists can be older than a recipe's visibility or status.
+	username := currentUsername(c)
+	visible := make([]models.SimilarRecipe, 0, len(similar))
+	for _, candidate := range similar {
+		if candidate.Recipe.CanView(username) && candidate.Recipe.IsListed() && candidate.Recipe.DeletedAt == nil {
+			candidate.Recipe.HideCollaborators(username)
+			visible = append(visible, candidate)
+		}
+	}
+	similar = visible
+
+	if len(similar) > similarParams.Limit {
+		similar = similar[:similarParams.Limit]
+	}
+	c.JSON(http.StatusOK, models.ListSimilarRecipes{
+		Count: len(similar),
+		Data:  similar,
+	})
+}
+
+// UpdateRecipeLabelsHandler godoc
+//
+//	@Summary		Override allergen and dietary labels
+//	@Description	Replace the detected allergen flags and dietary labels of a recipe. Only the author can override them.
+//	@Tags			recipes
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string						true	"Recipe ID"
+//	@Param			labels	body		models.UpdateRecipeLabels	true	"Labels"
+//	@Success		200		{object}	models.ViewRecipe
+//	@Failure		400		{object}	models.ErrorResponse
+//	@Failure		403		{object}	models.ErrorResponse
+//	@Failure		404		{object}	models.ErrorResponse
+//	@Failure		500		{object}	models.ErrorResponse
+//	@Router			/recipes/{id}/labels [put]
+func (handler *RecipeHandler) UpdateRecipeLabelsHandler(c *gin.Context) {
+	var updateLabels models.UpdateRecipeLabels
+	if err := c.ShouldBindJSON(&updateLabels); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Invalid Recipe labels",
+		})
+		return
+	}
+	for _, allergen := range updateLabels.Allergens {
+		if !dietary.IsAllergen(allergen) {
+			c.JSON(http.StatusBadRequest, models.ErrorResponse{
+				Code:    http.StatusBadRequest,
+				Message: fmt.Sprintf("Unknown allergen: %s", allergen),
+			})
+			return
+		}
+	}
+	for _, diet := range updateLabels.Diets {
+		if !dietary.IsDiet(diet) {
+			c.JSON(http.StatusBadRequest, models.ErrorResponse{
+				Code:    http.StatusBadRequest,
+				Message: fmt.Sprintf("Unknown diet: %s", diet),
+			})
+			return
+		}
+	}
+
+	handler.setRecipeLabels(c, dietary.Labels{Allergens: updateLabels.Allergens, Diets: updateLabels.Diets}, true)
+}
+
+// ResetRecipeLabelsHandler godoc
+//
+//	@Summary		Reset allergen and dietary labels
+//	@Description	Drop the author's override and detect the labels from the ingredients again
+//	@Tags			recipes
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		string	true	"Recipe ID"
+//	@Success		200	{object}	models.ViewRecipe
+//	@Failure		400	{object}	models.ErrorResponse
+//	@Failure		403	{object}	models.ErrorResponse
+//	@Failure		404	{object}	models.ErrorResponse
+//	@Failure		500	{object}	models.ErrorResponse
+//	@Router			/recipes/{id}/labels [delete]
+func (handler *RecipeHandler) ResetRecipeLabelsHandler(c *gin.Context) {
+	handler.setRecipeLabels(c, dietary.Labels{}, false)
+}
+
+func (handler *RecipeHandler) setRecipeLabels(c *gin.Context, labels dietary.Labels, overridden bool) {
+	id := c.Param("id")
+
+	objectID, err := bson.ObjectIDFromHex(id)
+	if err != nil {
+		log.Error().Str("ID", id).Msg("Invalid Recipe ID")
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Invalid Recipe ID",
+		})
+		return
+	}
+
+	recipe, found := handler.findRecipe(objectID)
+	if !found {
+		c.JSON(http.StatusNotFound, models.ErrorResponse{
+			Code:    http.StatusNotFound,
+			Message: fmt.Sprintf("Recipe not found with ID: %s", id),
+		})
+		return
+	}
+	if recipe.Author == "" || recipe.Author != currentUsername(c) {
+		c.JSON(http.StatusForbidden, models.ErrorResponse{
+			Code:    http.StatusForbidden,
+			Message: "Only the author can change the labels of a recipe",
+		})
+		return
+	}
+
+	if !overridden {
+		labels = dietary.Detect(recipe.Ingredients)
+	}
+	recipe.Allergens = labels.Allergens
+	recipe.Diets = labels.Diets
+	recipe.LabelsOverridden = overridden
+
+	filter := bson.D{{Key: "_id", Value: objectID}}
+	updateDoc := bson.D{{Key: "$set", Value: bson.D{
+		{Key: "allergens", Value: recipe.Allergens},
+		{Key: "diets", Value: recipe.Diets},
+		{Key: "labels_overridden", Value: recipe.LabelsOverridden},
+		{Key: "updated_at", Value: time.Now()},
+	}}}
+	if _, err := handler.collection.UpdateOne(handler.ctx, filter, updateDoc); err != nil {
+		log.Panic().Msg("Error updating recipe labels in MongoDB")
+		return
+	}
+
+	handler.InvalidateCache()
+	recipe.HideCollaborators(currentUsername(c))
+	c.JSON(http.StatusOK, recipe)
+}
+
+// newDraft prepares the first version of a recipe, as a draft by the author.
+func (handler *RecipeHandler) newDraft(addRecipe models.AddUpdateRecipe, author string) (models.Recipe, error) {
+	recipe := newRecipe(addRecipe)
+	recipe.Tags = handler.taxonomy().CanonicalAll(recipe.Tags)
+	if err := validateSteps(recipe.Instructions, len(recipe.Ingredients), nil); err != nil {
+		return recipe, err
+	}
+	recipe.Author = author
+	labels := dietary.Detect(recipe.Ingredients)
+	recipe.Allergens = labels.Allergens
+	recipe.Diets = labels.Diets
+	recipe.Version = 1
+	recipe.Status = models.StatusDraft
+	recipe.CreatedAt = time.Now()
+	recipe.UpdatedAt = recipe.CreatedAt
+	return recipe, nil
+}
+
+// newRecipe builds a recipe from submitted data, deriving nutrition and total time.
+func newRecipe(input models.AddUpdateRecipe) models.Recipe {
+	recipe := models.Recipe{
+		Name:          input.Name,
+		Tags:          input.Tags,
+		Ingredients:   input.Ingredients,
+		Instructions:  steps.Structure(input.Instructions),
+		Servings:      input.Servings,
+		PrepTime:      input.PrepTime,
+		CookTime:      input.CookTime,
+		TotalTime:     input.TotalTime,
+		Difficulty:    input.Difficulty,
+		Cuisine:       strings.ToLower(strings.TrimSpace(input.Cuisine)),
+		Course:        input.Course,
+		Equipment:     input.Equipment,
+		Yield:         input.Yield,
+		Attribution:   input.Attribution,
+		Visibility:    input.Visibility,
+		DefaultLocale: input.DefaultLocale,
+	}
+	if recipe.Visibility == "" {
+		recipe.Visibility = models.VisibilityPublic
+	}
+	if recipe.DefaultLocale == "" {
+		recipe.DefaultLocale = locale.Default
+	}
+
+	estimate := nutrition.Estimate(recipe.Ingredients, recipe.Servings)
+	recipe.Nutrition = &estimate
+
+	// Durations were validated when binding, so parse errors only leave zero values.
+	prepTime, _ := isoduration.Parse(recipe.PrepTime)
+	cookTime, _ := isoduration.Parse(recipe.CookTime)
+	totalTime, _ := isoduration.Parse(recipe.TotalTime)
+	if recipe.TotalTime == "" && prepTime+cookTime > 0 {
+		totalTime = prepTime + cookTime
+		recipe.TotalTime = isoduration.Format(totalTime)
+	}
+	recipe.TotalTimeMinutes = int(totalTime.Minutes())
+
+	return recipe
+}
+
+// validateSteps checks that steps only reference existing ingredients and images.
+func validateSteps(instructions models.Instructions, ingredientCount int, images []models.RecipeImage) error {
+	imageIDs := make(map[string]bool, len(images))
+	for _, image := range images {
+		imageIDs[image.ID] = true
+	}
+
+	for i, step := range instructions {
+		for _, ingredient := range step.Ingredients {
+			if ingredient >= ingredientCount {
+				return fmt.Errorf("Step %d references ingredient %d, but the recipe has %d ingredients", i+1, ingredient, ingredientCount)
+			}
+		}
+		if step.ImageID != "" && !imageIDs[step.ImageID] {
+			return fmt.Errorf("Step %d references unknown image %s, upload images before referencing them", i+1, step.ImageID)
+		}
+	}
+	return nil
+}
+
+// recipeContentFields lists the fields replaced when a recipe's content is updated.
+func recipeContentFields(recipe models.Recipe) bson.D {
+	return bson.D{
+		{Key: "name", Value: recipe.Name},
+		{Key: "tags", Value: recipe.Tags},
+		{Key: "ingredients", Value: recipe.Ingredients},
+		{Key: "instructions", Value: recipe.Instructions},
+		{Key: "servings", Value: recipe.Servings},
+		{Key: "nutrition", Value: recipe.Nutrition},
+		{Key: "prep_time", Value: recipe.PrepTime},
+		{Key: "cook_time", Value: recipe.CookTime},
+		{Key: "total_time", Value: recipe.TotalTime},
+		{Key: "total_time_minutes", Value: recipe.TotalTimeMinutes},
+		{Key: "difficulty", Value: recipe.Difficulty},
+		{Key: "cuisine", Value: recipe.Cuisine},
+		{Key: "course", Value: recipe.Course},
+		{Key: "equipment", Value: recipe.Equipment},
+		{Key: "yield", Value: recipe.Yield},
+		{Key: "attribution", Value: recipe.Attribution},
+	}
+}
+
+// recipeSettingFields lists the visibility and default locale when they were sent,
+// since leaving them out of an update keeps the current ones.
+func recipeSettingFields(input models.AddUpdateRecipe) bson.D {
+	fields := bson.D{}
+	if input.Visibility != "" {
+		fields = append(fields, bson.E{Key: "visibility", Value: input.Visibility})
+	}
+	if input.DefaultLocale != "" {
+		fields = append(fields, bson.E{Key: "default_locale", Value: input.DefaultLocale})
+	}
+	return fields
+}
+
+// UpdateRecipeStatusHandler godoc
+//
+//	@Summary		Change the status of a recipe
+//	@Description	Move a recipe between draft, in_review, published and archived. Publishing with a future publish_at schedules it instead; a background worker publishes it at that time. Only the author can change the status.
+//	@Tags			recipes
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string						true	"Recipe ID"
+//	@Param			status	body		models.UpdateRecipeStatus	true	"Status"
+//	@Success		200		{object}	models.ViewRecipe
+//	@Failure		400		{object}	models.ErrorResponse
+//	@Failure		403		{object}	models.ErrorResponse
+//	@Failure		404		{object}	models.ErrorResponse
+//	@Failure		500		{object}	models.ErrorResponse
+//	@Router			/recipes/{id}/status [put]
+func (handler *RecipeHandler) UpdateRecipeStatusHandler(c *gin.Context) {
+	var updateStatus models.UpdateRecipeStatus
+	if err := c.ShouldBindJSON(&updateStatus); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Invalid Recipe status",
+		})
+		return
+	}
+
+	recipe, ok := handler.bindRecipe(c)
+	if !ok {
+		return
+	}
+	if recipe.Author == "" || recipe.Author != currentUsername(c) {
+		c.JSON(http.StatusForbidden, models.ErrorResponse{
+			Code:    http.StatusForbidden,
+			Message: "Only the author can change the status of a recipe",
+		})
+		return
+	}
+
+	now := time.Now()
+	set := bson.D{{Key: "updated_at", Value: now}}
+	unset := bson.D{}
+	scheduled := updateStatus.Status == models.StatusPublished && updateStatus.PublishAt != nil && updateStatus.PublishAt.After(now)
+	switch {
+	case scheduled && recipe.IsPublished():
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Recipe is already published",
+		})
+		return
+	case scheduled:
+		set = append(set, bson.E{Key: "scheduled_at", Value: updateStatus.PublishAt})
+	default:
+		set = append(set, bson.E{Key: "status", Value: updateStatus.Status})
+		unset = append(unset, bson.E{Key: "scheduled_at", Value: ""})
+		if updateStatus.Status == models.StatusPublished && recipe.PublishedAt == nil {
+			set = append(set, bson.E{Key: "published_at", Value: now})
+		}
+	}
+
+	filter := bson.D{{Key: "_id", Value: recipe.ID}}
+	updateDoc := bson.D{{Key: "$set", Value: set}}
+	if len(unset) > 0 {
+		updateDoc = append(updateDoc, bson.E{Key: "$unset", Value: unset})
+	}
+	if _, err := handler.collection.UpdateOne(handler.ctx, filter, updateDoc); err != nil {
+		log.Panic().Msg("Error updating recipe status in MongoDB")
+		return
+	}
+
+	handler.InvalidateCache()
+	handler.indexRecipe(recipe.ID)
+	updated, _ := handler.findRecipe(recipe.ID)
+	updated.HideCollaborators(currentUsername(c))
+	c.JSON(http.StatusOK, updated)
+}
+
+// ListMyRecipesHandler godoc
+//
+//	@Summary		List my recipes
+//	@Description	Get the recipes the signed in user authored or collaborates on, in any status including drafts
+//	@Tags			recipes
+//	@Accept			json
+//	@Produce		json
+//	@Param			status	query		string	false	"Status"	Enums(draft, in_review, published, archived)
+//	@Success		200		{object}	models.ListRecipes
+//	@Failure		400		{object}	models.ErrorResponse
+//	@Failure		500		{object}	models.ErrorResponse
+//	@Router			/recipes/mine [get]
+func (handler *RecipeHandler) ListMyRecipesHandler(c *gin.Context) {
+	var statusParams models.RecipeStatusParams
+	if err := c.ShouldBindQuery(&statusParams); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Invalid Recipe status",
+		})
+		return
+	}
+
+	username := currentUsername(c)
+	filter := bson.D{
+		{Key: "$or", Value: bson.A{
+			bson.D{{Key: "author", Value: username}},
+			bson.D{{Key: "collaborators", Value: bson.D{{Key: "$elemMatch", Value: bson.D{
+				{Key: "username", Value: username},
+				{Key: "accepted_at", Value: bson.D{{Key: "$exists", Value: true}}},
+			}}}}},
+		}},
+		notDeletedFilter(),
+	}
+	if statusParams.Status != "" {
+		filter = append(filter, bson.E{Key: "status", Value: statusParams.Status})
+	}
+
+	recipes := handler.findRecipes(filter)
+	c.JSON(http.StatusOK, models.ListRecipes{
+		Count: len(recipes),
+		Data:  recipes,
+	})
+}
+
+func (handler *RecipeHandler) findRecipe(objectID bson.ObjectID) (models.ViewRecipe, bool) {
+	var recipe models.ViewRecipe
+	filter := bson.D{{Key: "_id", Value: objectID}, notDeletedFilter()}
+	err := handler.collection.FindOne(handler.ctx, filter).Decode(&recipe)
+	if err == mongo.ErrNoDocuments {
+		return recipe, false
+	} else if err != nil {
+		log.Panic().Msg("Error fetching recipe from MongoDB")
+	}
+	return recipe, true
+}
+
+// taxonomy returns the tag taxonomy. It is cached since every recipe write and
+// search needs it, and reloaded after taxonomyCacheTTL so that tag changes made
+// through other instances are picked up too.
+func (handler *RecipeHandler) taxonomy() *taxonomy.Taxonomy {
+	handler.tagsMutex.Lock()
+	defer handler.tagsMutex.Unlock()
+	if handler.tags == nil || time.Since(handler.tagsLoadedAt) > taxonomyCacheTTL {
+		handler.tags = taxonomy.New(findTags(handler.ctx, handler.tagCollection))
+		handler.tagsLoadedAt = time.Now()
+	}
+	return handler.tags
+}
+
+// TagsChanged drops the cached taxonomy after tags were written.
+func (handler *RecipeHandler) TagsChanged() {
+	handler.tagsMutex.Lock()
+	defer handler.tagsMutex.Unlock()
+	handler.tags = nil
+}
+
+func (handler *RecipeHandler) findRecipes(filter bson.D) []models.ViewRecipe {
+	cursor, err := handler.collection.Find(handler.ctx, filter)
+	if err != nil {
+		log.Panic().Msg("Error fetching recipes from MongoDB")
+	}
 	defer cursor.Close(handler.ctx)
 
 	recipes := make([]models.ViewRecipe, 0)
@@ -318,13 +1053,224 @@ func (handler *RecipeHandler) SearchRecipeHandler(c *gin.Context) {
 		var recipe models.ViewRecipe
 		if err := cursor.Decode(&recipe); err != nil {
 			log.Panic().Msg("Error decoding recipe from MongoDB")
-			return
 		}
 		recipes = append(recipes, recipe)
 	}
+	return recipes
+}
 
-	c.JSON(http.StatusOK, models.ListRecipes{
-		Count: len(recipes),
-		Data:  recipes,
-	})
+// publishedFilter matches public recipes, including those created before recipes had a status.
+func publishedFilter() bson.E {
+	return bson.E{Key: "status", Value: bson.D{{Key: "$in", Value: bson.A{models.StatusPublished, nil}}}}
+}
+
+// listedFilter matches recipes that appear in lists, leaving out unlisted and private ones.
+func listedFilter() bson.E {
+	return bson.E{Key: "visibility", Value: bson.D{{Key: "$in", Value: bson.A{models.VisibilityPublic, nil}}}}
+}
+
+// notDeletedFilter matches recipes that are not in the trash.
+func notDeletedFilter() bson.E {
+	return bson.E{Key: "deleted_at", Value: bson.D{{Key: "$exists", Value: false}}}
+}
+
+// recipeFilter builds the Mongo filter shared by the list and search endpoints.
+func recipeFilter(params models.RecipeFilterParams) (bson.D, error) {
+	filter := bson.D{}
+
+	if allergens := splitList(params.ExcludeAllergens); len(allergens) > 0 {
+		for _, allergen := range allergens {
+			if !dietary.IsAllergen(allergen) {
+				return nil, fmt.Errorf("Unknown allergen: %s, expected one of %s", allergen, strings.Join(dietary.Allergens(), ", "))
+			}
+		}
+		filter = append(filter, bson.E{Key: "allergens", Value: bson.D{{Key: "$nin", Value: allergens}}})
+	}
+
+	if diets := splitList(params.Diet); len(diets) > 0 {
+		for _, diet := range diets {
+			if !dietary.IsDiet(diet) {
+				return nil, fmt.Errorf("Unknown diet: %s, expected one of %s", diet, strings.Join(dietary.Diets(), ", "))
+			}
+		}
+		filter = append(filter, bson.E{Key: "diets", Value: bson.D{{Key: "$all", Value: diets}}})
+	}
+
+	if params.MaxTotalTime != "" {
+		maxTotalTime, err := time.ParseDuration(params.MaxTotalTime)
+		if err != nil {
+			maxTotalTime, err = isoduration.Parse(params.MaxTotalTime)
+		}
+		if err != nil || maxTotalTime <= 0 {
+			return nil, fmt.Errorf("Invalid max_total_time: %s, expected a duration such as 30m or PT30M", params.MaxTotalTime)
+		}
+		filter = append(filter, bson.E{Key: "total_time_minutes", Value: bson.D{
+			{Key: "$gt", Value: 0},
+			{Key: "$lte", Value: int(maxTotalTime.Minutes())},
+		}})
+	}
+
+	if err := validateDifficulties(splitList(params.Difficulty)); err != nil {
+		return nil, err
+	}
+
+	for _, field := range []struct{ key, value string }{
+		{"cuisine", params.Cuisine},
+		{"course", params.Course},
+		{"difficulty", params.Difficulty},
+	} {
+		if values := splitList(field.value); len(values) > 0 {
+			filter = append(filter, matchAny(field.key, values))
+		}
+	}
+
+	return filter, nil
+}
+
+func validateDifficulties(values []string) error {
+	for _, difficulty := range values {
+		if !slices.Contains(difficulties, difficulty) {
+			return fmt.Errorf("Unknown difficulty: %s, expected one of %s", difficulty, strings.Join(difficulties, ", "))
+		}
+	}
+	return nil
+}
+
+// idsFilter matches the recipes with the given hex IDs.
+func idsFilter(ids []string) bson.E {
+	objectIDs := make(bson.A, 0, len(ids))
+	for _, id := range ids {
+		if objectID, err := bson.ObjectIDFromHex(id); err == nil {
+			objectIDs = append(objectIDs, objectID)
+		}
+	}
+	return bson.E{Key: "_id", Value: bson.D{{Key: "$in", Value: objectIDs}}}
+}
+
+// haveFilter matches the recipes within base that use at least one of items, matched
+// the same way as the pantry.
+func (handler *RecipeHandler) haveFilter(base bson.D, items []string) bson.E {
+	opts := options.Find().SetProjection(bson.D{{Key: "ingredients", Value: 1}})
+	cursor, err := handler.collection.Find(handler.ctx, base, opts)
+	if err != nil {
+		log.Panic().Msg("Error fetching recipes from MongoDB")
+	}
+	defer cursor.Close(handler.ctx)
+
+	ids := make([]string, 0)
+	for cursor.Next(handler.ctx) {
+		var recipe models.ViewRecipe
+		if err := cursor.Decode(&recipe); err != nil {
+			log.Panic().Msg("Error decoding recipe from MongoDB")
+		}
+		if ingredients.Match(items, recipe.Ingredients).Matched > 0 {
+			ids = append(ids, recipe.ID.Hex())
+		}
+	}
+	return idsFilter(ids)
+}
+
+// matchAny matches documents whose field equals any of the values, ignoring case.
+func matchAny(key string, values []string) bson.E {
+	patterns := make(bson.A, 0, len(values))
+	for _, value := range values {
+		patterns = append(patterns, bson.Regex{Pattern: "^" + regexp.QuoteMeta(value) + "$", Options: "i"})
+	}
+	if len(patterns) == 1 {
+		return bson.E{Key: key, Value: patterns[0]}
+	}
+	return bson.E{Key: key, Value: bson.D{{Key: "$in", Value: patterns}}}
+}
+
+func splitList(value string) []string {
+	items := make([]string, 0)
+	for _, item := range strings.Split(value, ",") {
+		item = strings.ToLower(strings.TrimSpace(item))
+		if item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+func (handler *RecipeHandler) computeSimilarRecipes(id string) ([]models.SimilarRecipe, bool) {
+	objectID, _ := bson.ObjectIDFromHex(id)
+	filter := bson.D{{Key: "$or", Value: bson.A{
+		bson.D{publishedFilter(), listedFilter()},
+		bson.D{{Key: "_id", Value: objectID}},
+	}}, notDeletedFilter()}
+	cursor, err := handler.collection.Find(handler.ctx, filter)
+	if err != nil {
+		log.Panic().Msg("Error fetching recipes from MongoDB")
+	}
+	defer cursor.Close(handler.ctx)
+
+	recipes := make(map[string]models.ViewRecipe)
+	documents := make([]similarity.Document, 0)
+	for cursor.Next(handler.ctx) {
+		var recipe models.ViewRecipe
+		if err := cursor.Decode(&recipe); err != nil {
+			log.Panic().Msg("Error decoding recipe from MongoDB")
+		}
+		recipes[recipe.ID.Hex()] = recipe
+		documents = append(documents, similarity.Document{
+			ID:          recipe.ID.Hex(),
+			Ingredients: recipe.Ingredients,
+			Tags:        recipe.Tags,
+		})
+	}
+	if _, ok := recipes[id]; !ok {
+		return nil, false
+	}
+
+	similar := make([]models.SimilarRecipe, 0)
+	for _, scored := range similarity.NewModel(documents).Similar(id, maxSimilarRecipes) {
+		if !recipes[scored.ID].IsListed() {
+			continue
+		}
+		similar = append(similar, models.SimilarRecipe{
+			Recipe: recipes[scored.ID],
+			Score:  scored.Score,
+		})
+	}
+	return similar, true
+}
+
+func (handler *RecipeHandler) cachedSimilarRecipes(id string) ([]models.SimilarRecipe, bool) {
+	if !handler.config.EnableRedisCache {
+		return nil, false
+	}
+
+	result, err := handler.redisClient.HGet(handler.ctx, similarRecipesCacheKey, id).Result()
+	if err == redis.Nil {
+		return nil, false
+	} else if err != nil {
+		log.Panic().Msg("Error fetching similar recipes from Redis cache")
+	}
+
+	similar := make([]models.SimilarRecipe, 0)
+	if err := json.Unmarshal([]byte(result), &similar); err != nil {
+		log.Panic().Msg("Error unmarshalling similar recipes from Redis cache to JSON")
+	}
+	return similar, true
+}
+
+func (handler *RecipeHandler) cacheSimilarRecipes(id string, similar []models.SimilarRecipe) {
+	if !handler.config.EnableRedisCache {
+		return
+	}
+
+	data, err := json.Marshal(similar)
+	if err != nil {
+		log.Panic().Msg("Error marshalling similar recipes to JSON")
+	}
+	handler.redisClient.HSet(handler.ctx, similarRecipesCacheKey, id, string(data))
+}
+
+// InvalidateCache drops the cached recipe lists so the next read goes to MongoDB.
+func (handler *RecipeHandler) InvalidateCache() {
+	if handler.config.EnableRedisCache {
+		log.Info().Msg("Removing recipes from Redis cache...")
+		handler.redisClient.Del(handler.ctx, recipesCacheKey, similarRecipesCacheKey)
+	}
 }
@@ -0,0 +1,138 @@
+package handlers
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mahesh-yadav/go-recipes-api/models"
+	"github.com/mahesh-yadav/go-recipes-api/search"
+	"github.com/rs/zerolog/log"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+const defaultSemanticResults = 10
+
+// embeddedRecipe is a recipe along with its stored embedding, which is not part of
+// the API.
+type embeddedRecipe struct {
+	models.ViewRecipe `bson:",inline"`
+	Embedding         *models.RecipeEmbedding `bson:"embedding,omitempty"`
+}
+
+// SemanticSearchHandler godoc
+//
+//	@Summary		Search recipes by meaning
+//	@Description	Get the published recipes closest in meaning to a free text description, e.g. cozy winter soup, best first
+//	@Tags			recipes
+//	@Accept			json
+//	@Produce		json
+//	@Param			q				query		string	true	"Description of the recipe"
+//	@Param			limit			query		int		false	"Maximum number of recipes to return"
+//	@Param			Accept-Language	header		string	false	"Preferred locales, e.g. es, en;q=0.8"
+//	@Success		200				{object}	models.ListSimilarRecipes
+//	@Failure		400				{object}	models.ErrorResponse
+//	@Failure		500				{object}	models.ErrorResponse
+//	@Router			/recipes/semantic [get]
+func (handler *RecipeHandler) SemanticSearchHandler(c *gin.Context) {
+	var semanticParams models.SemanticSearchParams
+	if err := c.ShouldBindQuery(&semanticParams); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Invalid semantic search parameters",
+		})
+		return
+	}
+	if semanticParams.Limit == 0 {
+		semanticParams.Limit = defaultSemanticResults
+	}
+
+	scored := handler.semanticIndex.Search(handler.embedder.Embed(semanticParams.Query), semanticParams.Limit)
+	ids := make([]string, 0, len(scored))
+	for _, result := range scored {
+		ids = append(ids, result.ID)
+	}
+	recipes := make(map[string]models.ViewRecipe, len(ids))
+	for _, recipe := range handler.findRecipes(bson.D{idsFilter(ids), publishedFilter(), listedFilter(), notDeletedFilter()}) {
+		recipes[recipe.ID.Hex()] = recipe
+	}
+
+	found := make([]models.ViewRecipe, 0, len(scored))
+	scores := make([]float64, 0, len(scored))
+	for _, result := range scored {
+		if recipe, ok := recipes[result.ID]; ok {
+			found = append(found, recipe)
+			scores = append(scores, result.Score)
+		}
+	}
+	localizeRecipes(c, found)
+	hideCollaborators(c, found)
+
+	results := make([]models.SimilarRecipe, 0, len(found))
+	for i, recipe := range found {
+		results = append(results, models.SimilarRecipe{Recipe: recipe, Score: scores[i]})
+	}
+
+	c.JSON(http.StatusOK, models.ListSimilarRecipes{
+		Count: len(results),
+		Data:  results,
+	})
+}
+
+// buildSemanticIndex embeds every listed recipe, storing new embeddings, and
+// replaces the vectors of the semantic index.
+func (handler *RecipeHandler) buildSemanticIndex() {
+	cursor, err := handler.collection.Find(handler.ctx, bson.D{publishedFilter(), listedFilter(), notDeletedFilter()})
+	if err != nil {
+		log.Panic().Msg("Error fetching recipes from MongoDB")
+	}
+	var recipes []embeddedRecipe
+	if err := cursor.All(handler.ctx, &recipes); err != nil {
+		log.Panic().Msg("Error decoding recipes from MongoDB")
+	}
+
+	vectors := make(map[string][]float32, len(recipes))
+	for _, recipe := range recipes {
+		vectors[recipe.ID.Hex()] = handler.recipeEmbedding(recipe)
+	}
+	handler.semanticIndex.Reset(vectors)
+	log.Info().Int("recipes", len(vectors)).Str("model", handler.embedder.Model()).Msg("Built semantic index")
+}
+
+// recipeEmbedding returns the stored vector of a recipe, embedding and storing it
+// again when its text or the embedder changed.
+func (handler *RecipeHandler) recipeEmbedding(recipe embeddedRecipe) []float32 {
+	text := embeddingText(recipe.ViewRecipe)
+	sum := sha256.Sum256([]byte(handler.embedder.Model() + "\n" + text))
+	hash := hex.EncodeToString(sum[:])
+	if recipe.Embedding != nil && recipe.Embedding.Hash == hash {
+		return recipe.Embedding.Vector
+	}
+
+	embedding := models.RecipeEmbedding{
+		Model:  handler.embedder.Model(),
+		Hash:   hash,
+		Vector: handler.embedder.Embed(text),
+	}
+	_, err := handler.collection.UpdateOne(handler.ctx, bson.D{{Key: "_id", Value: recipe.ID}}, bson.D{{Key: "$set", Value: bson.D{
+		{Key: "embedding", Value: embedding},
+	}}})
+	if err != nil {
+		log.Panic().Msg("Error storing recipe embedding in MongoDB")
+	}
+	return embedding.Vector
+}
+
+// embeddingText is the text describing a recipe, with the name repeated so that it
+// weighs more than any single ingredient or step.
+func embeddingText(recipe models.ViewRecipe) string {
+	parts := []string{recipe.Name, recipe.Name, recipe.Cuisine, recipe.Course}
+	parts = append(parts, recipe.Tags...)
+	parts = append(parts, search.IngredientItems(recipe.Ingredients)...)
+	for _, step := range recipe.Instructions {
+		parts = append(parts, step.Section, step.Text)
+	}
+	return strings.Join(parts, "\n")
+}
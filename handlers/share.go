@@ -0,0 +1,148 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mahesh-yadav/go-recipes-api/models"
+	"github.com/mahesh-yadav/go-recipes-api/sharing"
+	"github.com/rs/zerolog/log"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+const defaultShareLinkHours = 24
+
+// ShareRecipeHandler godoc
+//
+//	@Summary		Create a share link
+//	@Description	Create a signed link that lets anyone read the recipe, including unlisted and private ones, until it expires. Only the author can share a recipe.
+//	@Tags			recipes
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string				true	"Recipe ID"
+//	@Param			share	body		models.ShareRecipe	false	"Link expiry"
+//	@Success		201		{object}	models.ShareLink
+//	@Failure		400		{object}	models.ErrorResponse
+//	@Failure		403		{object}	models.ErrorResponse
+//	@Failure		404		{object}	models.ErrorResponse
+//	@Failure		500		{object}	models.ErrorResponse
+//	@Router			/recipes/{id}/share [post]
+func (handler *RecipeHandler) ShareRecipeHandler(c *gin.Context) {
+	var share models.ShareRecipe
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&share); err != nil {
+			c.JSON(http.StatusBadRequest, models.ErrorResponse{
+				Code:    http.StatusBadRequest,
+				Message: "Invalid share link expiry",
+			})
+			return
+		}
+	}
+	if share.ExpiresInHours == 0 {
+		share.ExpiresInHours = defaultShareLinkHours
+	}
+
+	recipe, ok := handler.bindRecipe(c)
+	if !ok {
+		return
+	}
+	if recipe.Author == "" || recipe.Author != currentUsername(c) {
+		c.JSON(http.StatusForbidden, models.ErrorResponse{
+			Code:    http.StatusForbidden,
+			Message: "Only the author can share this recipe",
+		})
+		return
+	}
+
+	id := recipe.ID.Hex()
+	expires := time.Now().Add(time.Duration(share.ExpiresInHours) * time.Hour).Truncate(time.Second)
+	query := url.Values{}
+	query.Set("expires", fmt.Sprint(expires.Unix()))
+	query.Set("signature", sharing.Sign(handler.shareSecret(), id, expires))
+
+	scheme := "http"
+	if c.Request.TLS != nil {
+		scheme = "https"
+	}
+	link := url.URL{
+		Scheme:   scheme,
+		Host:     c.Request.Host,
+		Path:     "/shared/recipes/" + id,
+		RawQuery: query.Encode(),
+	}
+
+	c.JSON(http.StatusCreated, models.ShareLink{
+		URL:       link.String(),
+		ExpiresAt: expires,
+	})
+}
+
+// GetSharedRecipeHandler godoc
+//
+//	@Summary		Get a shared recipe
+//	@Description	Get a recipe through a signed share link, without signing in, optionally exported like GET /recipes/{id}
+//	@Tags			recipes
+//	@Accept			json
+//	@Produce		json,application/ld+json,text/markdown,plain
+//	@Param			id			path		string	true	"Recipe ID"
+//	@Param			expires		query		int		true	"Expiry as a Unix timestamp"
+//	@Param			signature	query		string	true	"Link signature"
+//	@Param			format		query		string	false	"Export format, e.g. jsonld, markdown or text"
+//	@Success		200			{object}	models.ViewRecipe
+//	@Failure		400			{object}	models.ErrorResponse
+//	@Failure		403			{object}	models.ErrorResponse
+//	@Failure		404			{object}	models.ErrorResponse
+//	@Failure		500			{object}	models.ErrorResponse
+//	@Router			/shared/recipes/{id} [get]
+func (handler *RecipeHandler) GetSharedRecipeHandler(c *gin.Context) {
+	var params models.ShareLinkParams
+	if err := c.ShouldBindQuery(&params); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Invalid share link",
+		})
+		return
+	}
+
+	id := c.Param("id")
+	expires := time.Unix(params.Expires, 0)
+	if err := sharing.Verify(handler.shareSecret(), id, expires, params.Signature, time.Now()); err != nil {
+		log.Warn().Str("ID", id).Err(err).Msg("Rejected share link")
+		c.JSON(http.StatusForbidden, models.ErrorResponse{
+			Code:    http.StatusForbidden,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	objectID, err := bson.ObjectIDFromHex(id)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Invalid Recipe ID",
+		})
+		return
+	}
+	recipe, found := handler.findRecipe(objectID)
+	if !found {
+		c.JSON(http.StatusNotFound, models.ErrorResponse{
+			Code:    http.StatusNotFound,
+			Message: fmt.Sprintf("Recipe not found with ID: %s", id),
+		})
+		return
+	}
+
+	localizeRecipe(c, &recipe)
+	recipe.HideCollaborators("")
+	writeRecipe(c, recipe)
+}
+
+func (handler *RecipeHandler) shareSecret() []byte {
+	if handler.config.ShareLinkSecret != "" {
+		return []byte(handler.config.ShareLinkSecret)
+	}
+	return []byte(handler.config.JWTSecret)
+}
@@ -0,0 +1,160 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mahesh-yadav/go-recipes-api/models"
+	"github.com/mahesh-yadav/go-recipes-api/search"
+	"github.com/rs/zerolog/log"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+const defaultSuggestions = 8
+
+// SuggestHandler godoc
+//
+//	@Summary		Autocomplete search terms
+//	@Description	Get recipe names, tags and ingredients completing the typed text, tolerating typos
+//	@Tags			search
+//	@Accept			json
+//	@Produce		json
+//	@Param			q		query		string	true	"Typed text"
+//	@Param			limit	query		int		false	"Maximum number of suggestions"
+//	@Success		200		{object}	models.ListSuggestions
+//	@Failure		400		{object}	models.ErrorResponse
+//	@Router			/suggest [get]
+func (handler *RecipeHandler) SuggestHandler(c *gin.Context) {
+	var suggestParams models.SuggestParams
+	if err := c.ShouldBindQuery(&suggestParams); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Invalid suggest parameters",
+		})
+		return
+	}
+	if suggestParams.Limit == 0 {
+		suggestParams.Limit = defaultSuggestions
+	}
+
+	suggestions := make([]models.Suggestion, 0)
+	for _, suggestion := range handler.suggester.Suggest(suggestParams.Query, suggestParams.Limit) {
+		suggestions = append(suggestions, models.Suggestion{
+			Text:  suggestion.Text,
+			Type:  suggestion.Kind,
+			Count: suggestion.Count,
+		})
+	}
+
+	c.JSON(http.StatusOK, models.ListSuggestions{
+		Count: len(suggestions),
+		Data:  suggestions,
+	})
+}
+
+// ReindexHandler godoc
+//
+//	@Summary		Rebuild the search index
+//	@Description	Rebuild the search and autocomplete indexes from the recipes collection
+//	@Tags			search
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header		string	true	"{token}"
+//	@Success		200				{object}	models.ReindexResult
+//	@Failure		401				{object}	models.ErrorResponse
+//	@Failure		403				{object}	models.ErrorResponse
+//	@Failure		500				{object}	models.ErrorResponse
+//	@Router			/admin/reindex [post]
+func (handler *RecipeHandler) ReindexHandler(c *gin.Context) {
+	count, err := handler.Reindex()
+	if err != nil {
+		log.Panic().Err(err).Msg("Error rebuilding the search index")
+		return
+	}
+	c.JSON(http.StatusOK, models.ReindexResult{Count: count})
+}
+
+// LoadSearchIndex fills the autocomplete index from the search index, rebuilding
+// both from MongoDB when the search index keeps no documents of its own.
+func (handler *RecipeHandler) LoadSearchIndex() error {
+	documents, ok, err := handler.searchIndex.Documents(handler.ctx)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		_, err := handler.Reindex()
+		return err
+	}
+	handler.suggester.Reset(documents)
+	log.Info().Int("recipes", len(documents)).Msg("Loaded search index")
+	handler.buildSemanticIndex()
+	return nil
+}
+
+// Reindex rebuilds the search, autocomplete and semantic indexes from every listed recipe
+// and returns the number of recipes indexed.
+func (handler *RecipeHandler) Reindex() (int, error) {
+	recipes := handler.findRecipes(bson.D{publishedFilter(), listedFilter(), notDeletedFilter()})
+	documents := make([]search.Document, 0, len(recipes))
+	for _, recipe := range recipes {
+		documents = append(documents, searchDocument(recipe))
+	}
+	if err := handler.searchIndex.Rebuild(handler.ctx, documents); err != nil {
+		return 0, err
+	}
+	handler.suggester.Reset(documents)
+	log.Info().Int("recipes", len(documents)).Msg("Rebuilt search index")
+	handler.buildSemanticIndex()
+	return len(documents), nil
+}
+
+// RecipesChanged is called after recipes were changed in bulk, outside of the
+// recipe handlers, to refresh the cache and the search indexes.
+func (handler *RecipeHandler) RecipesChanged() {
+	handler.InvalidateCache()
+	if _, err := handler.Reindex(); err != nil {
+		log.Error().Err(err).Msg("Error rebuilding the search index")
+	}
+}
+
+// RecipesPublished is called after the scheduler published recipes, to refresh the
+// cache and index just those recipes.
+func (handler *RecipeHandler) RecipesPublished(ids []bson.ObjectID) {
+	handler.InvalidateCache()
+	for _, id := range ids {
+		handler.indexRecipe(id)
+	}
+}
+
+// indexRecipe updates the search indexes after a recipe was written, dropping
+// recipes that are no longer listed.
+func (handler *RecipeHandler) indexRecipe(id bson.ObjectID) {
+	var recipe embeddedRecipe
+	err := handler.collection.FindOne(handler.ctx, bson.D{{Key: "_id", Value: id}}).Decode(&recipe)
+	if err != nil && err != mongo.ErrNoDocuments {
+		log.Panic().Msg("Error fetching recipe from MongoDB")
+	}
+	if err == nil && recipe.IsListed() && recipe.DeletedAt == nil {
+		document := searchDocument(recipe.ViewRecipe)
+		handler.suggester.Upsert(document)
+		handler.semanticIndex.Upsert(id.Hex(), handler.recipeEmbedding(recipe))
+		err = handler.searchIndex.Upsert(handler.ctx, document)
+	} else {
+		handler.suggester.Remove(id.Hex())
+		handler.semanticIndex.Remove(id.Hex())
+		err = handler.searchIndex.Remove(handler.ctx, id.Hex())
+	}
+	if err != nil {
+		log.Error().Err(err).Str("ID", id.Hex()).Msg("Error updating the search index")
+	}
+}
+
+func searchDocument(recipe models.ViewRecipe) search.Document {
+	return search.Document{
+		ID:          recipe.ID.Hex(),
+		Name:        recipe.Name,
+		Tags:        recipe.Tags,
+		Ingredients: recipe.Ingredients,
+	}
+}
@@ -0,0 +1,456 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"sort"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mahesh-yadav/go-recipes-api/models"
+	"github.com/mahesh-yadav/go-recipes-api/taxonomy"
+	"github.com/rs/zerolog/log"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+type TagHandler struct {
+	ctx              context.Context
+	collection       *mongo.Collection
+	recipeCollection *mongo.Collection
+	recipes          *RecipeHandler
+}
+
+func NewTagHandler(ctx context.Context, collection *mongo.Collection, recipeCollection *mongo.Collection, recipes *RecipeHandler) *TagHandler {
+	return &TagHandler{
+		ctx:              ctx,
+		collection:       collection,
+		recipeCollection: recipeCollection,
+		recipes:          recipes,
+	}
+}
+
+// ListTagsHandler godoc
+//
+//	@Summary		List tags
+//	@Description	Get the managed tags and the tags used by published recipes, with the number of recipes using each
+//	@Tags			tags
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{object}	models.ListTags
+//	@Failure		500	{object}	models.ErrorResponse
+//	@Router			/tags [get]
+func (handler *TagHandler) ListTagsHandler(c *gin.Context) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.D{publishedFilter(), listedFilter(), notDeletedFilter()}}},
+		{{Key: "$unwind", Value: "$tags"}},
+		{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: "$tags"},
+			{Key: "count", Value: bson.D{{Key: "$sum", Value: 1}}},
+		}}},
+	}
+	cursor, err := handler.recipeCollection.Aggregate(handler.ctx, pipeline)
+	if err != nil {
+		log.Panic().Msg("Error counting recipe tags in MongoDB")
+		return
+	}
+	var counts []struct {
+		Tag   string `bson:"_id"`
+		Count int    `bson:"count"`
+	}
+	if err := cursor.All(handler.ctx, &counts); err != nil {
+		log.Panic().Msg("Error decoding recipe tag counts from MongoDB")
+		return
+	}
+
+	usage := make(map[string]*models.TagUsage)
+	for _, tag := range handler.findTags() {
+		usage[tag.Slug] = &models.TagUsage{Tag: tag, Managed: true}
+	}
+	for _, count := range counts {
+		if _, ok := usage[count.Tag]; !ok {
+			usage[count.Tag] = &models.TagUsage{Tag: models.Tag{Slug: count.Tag, Name: count.Tag, Synonyms: []string{}}}
+		}
+		usage[count.Tag].Count = count.Count
+	}
+
+	tags := make([]models.TagUsage, 0, len(usage))
+	for _, tag := range usage {
+		tags = append(tags, *tag)
+	}
+	sort.Slice(tags, func(i, j int) bool {
+		if tags[i].Count != tags[j].Count {
+			return tags[i].Count > tags[j].Count
+		}
+		return tags[i].Slug < tags[j].Slug
+	})
+
+	c.JSON(http.StatusOK, models.ListTags{
+		Count: len(tags),
+		Data:  tags,
+	})
+}
+
+// CreateTagHandler godoc
+//
+//	@Summary		Create a tag
+//	@Description	Add a tag to the taxonomy. Recipes tagged with one of its synonyms are retagged with it. Admins only.
+//	@Tags			tags
+//	@Accept			json
+//	@Produce		json
+//	@Param			tag	body		models.AddUpdateTag	true	"Tag"
+//	@Success		201	{object}	models.Tag
+//	@Failure		400	{object}	models.ErrorResponse
+//	@Failure		403	{object}	models.ErrorResponse
+//	@Failure		409	{object}	models.ErrorResponse
+//	@Failure		500	{object}	models.ErrorResponse
+//	@Router			/tags [post]
+func (handler *TagHandler) CreateTagHandler(c *gin.Context) {
+	var addTag models.AddUpdateTag
+	if err := c.ShouldBindJSON(&addTag); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Invalid Tag data",
+		})
+		return
+	}
+
+	tags := taxonomy.New(handler.findTags())
+	tag, err := newTag(addTag, tags, "")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Code:    http.StatusBadRequest,
+			Message: err.Error(),
+		})
+		return
+	}
+	if canonical := tags.Canonical(tag.Slug); canonical != tag.Slug || tagExists(tags, tag.Slug) {
+		c.JSON(http.StatusConflict, models.ErrorResponse{
+			Code:    http.StatusConflict,
+			Message: fmt.Sprintf("Tag %s already exists as %s", tag.Slug, canonical),
+		})
+		return
+	}
+
+	if _, err := handler.collection.InsertOne(handler.ctx, tag); err != nil {
+		log.Panic().Msg("Error inserting tag into MongoDB")
+		return
+	}
+	handler.rewriteRecipeTags(tag.Synonyms, tag.Slug, currentUsername(c))
+
+	c.JSON(http.StatusCreated, tag)
+}
+
+// UpdateTagHandler godoc
+//
+//	@Summary		Update or rename a tag
+//	@Description	Change a tag's name, synonyms or parent. Changing its slug renames it: recipes and child tags are rewritten and the old slug becomes a synonym. Admins only.
+//	@Tags			tags
+//	@Accept			json
+//	@Produce		json
+//	@Param			slug	path		string				true	"Tag slug"
+//	@Param			tag		body		models.AddUpdateTag	true	"Tag"
+//	@Success		200		{object}	models.Tag
+//	@Failure		400		{object}	models.ErrorResponse
+//	@Failure		403		{object}	models.ErrorResponse
+//	@Failure		404		{object}	models.ErrorResponse
+//	@Failure		409		{object}	models.ErrorResponse
+//	@Failure		500		{object}	models.ErrorResponse
+//	@Router			/tags/{slug} [put]
+func (handler *TagHandler) UpdateTagHandler(c *gin.Context) {
+	var updateTag models.AddUpdateTag
+	if err := c.ShouldBindJSON(&updateTag); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Invalid Tag data",
+		})
+		return
+	}
+
+	tags := taxonomy.New(handler.findTags())
+	existing, ok := bindTag(c, tags)
+	if !ok {
+		return
+	}
+	if updateTag.Slug == "" {
+		updateTag.Slug = existing.Slug
+	}
+
+	tag, err := newTag(updateTag, tags, existing.Slug)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Code:    http.StatusBadRequest,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	renamed := tag.Slug != existing.Slug
+	if renamed {
+		if canonical := tags.Canonical(tag.Slug); tagExists(tags, canonical) && canonical != existing.Slug {
+			c.JSON(http.StatusConflict, models.ErrorResponse{
+				Code:    http.StatusConflict,
+				Message: fmt.Sprintf("Tag %s already exists as %s, merge the tags instead", tag.Slug, canonical),
+			})
+			return
+		}
+		tag.Synonyms = appendSynonyms(tag.Synonyms, tag.Slug, existing.Slug)
+	}
+
+	filter := bson.D{{Key: "_id", Value: existing.Slug}}
+	if renamed {
+		// Slugs are document IDs, so renaming replaces the document.
+		if _, err := handler.collection.InsertOne(handler.ctx, tag); err != nil {
+			log.Panic().Msg("Error inserting tag into MongoDB")
+			return
+		}
+		if _, err := handler.collection.DeleteOne(handler.ctx, filter); err != nil {
+			log.Panic().Msg("Error deleting tag from MongoDB")
+			return
+		}
+		handler.reparentTags(existing.Slug, tag.Slug)
+	} else if _, err := handler.collection.ReplaceOne(handler.ctx, filter, tag); err != nil {
+		log.Panic().Msg("Error updating tag in MongoDB")
+		return
+	}
+	handler.rewriteRecipeTags(tag.Synonyms, tag.Slug, currentUsername(c))
+
+	c.JSON(http.StatusOK, tag)
+}
+
+// MergeTagHandler godoc
+//
+//	@Summary		Merge a tag into another
+//	@Description	Retag recipes using the tag with the target tag, move its children to the target and keep its slug and synonyms as synonyms of the target. Admins only.
+//	@Tags			tags
+//	@Accept			json
+//	@Produce		json
+//	@Param			slug	path		string			true	"Tag slug"
+//	@Param			merge	body		models.MergeTag	true	"Target tag"
+//	@Success		200		{object}	models.Tag
+//	@Failure		400		{object}	models.ErrorResponse
+//	@Failure		403		{object}	models.ErrorResponse
+//	@Failure		404		{object}	models.ErrorResponse
+//	@Failure		500		{object}	models.ErrorResponse
+//	@Router			/tags/{slug}/merge [post]
+func (handler *TagHandler) MergeTagHandler(c *gin.Context) {
+	var merge models.MergeTag
+	if err := c.ShouldBindJSON(&merge); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Invalid merge target",
+		})
+		return
+	}
+
+	tags := taxonomy.New(handler.findTags())
+	source, ok := bindTag(c, tags)
+	if !ok {
+		return
+	}
+	target, ok := tags.Get(tags.Canonical(merge.Into))
+	if !ok {
+		c.JSON(http.StatusNotFound, models.ErrorResponse{
+			Code:    http.StatusNotFound,
+			Message: fmt.Sprintf("Tag not found: %s", merge.Into),
+		})
+		return
+	}
+	if target.Slug == source.Slug || tags.IsAncestor(source.Slug, target.Slug) {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Code:    http.StatusBadRequest,
+			Message: "A tag cannot be merged into itself or one of its children",
+		})
+		return
+	}
+
+	target.Synonyms = appendSynonyms(target.Synonyms, target.Slug, append(source.Synonyms, source.Slug)...)
+	filter := bson.D{{Key: "_id", Value: target.Slug}}
+	update := bson.D{{Key: "$set", Value: bson.D{{Key: "synonyms", Value: target.Synonyms}}}}
+	if _, err := handler.collection.UpdateOne(handler.ctx, filter, update); err != nil {
+		log.Panic().Msg("Error updating tag in MongoDB")
+		return
+	}
+	if _, err := handler.collection.DeleteOne(handler.ctx, bson.D{{Key: "_id", Value: source.Slug}}); err != nil {
+		log.Panic().Msg("Error deleting tag from MongoDB")
+		return
+	}
+	handler.reparentTags(source.Slug, target.Slug)
+	handler.rewriteRecipeTags(target.Synonyms, target.Slug, currentUsername(c))
+
+	c.JSON(http.StatusOK, target)
+}
+
+// DeleteTagHandler godoc
+//
+//	@Summary		Delete a tag
+//	@Description	Remove a tag from the taxonomy, moving its children to its parent. Recipes keep the tag. Admins only.
+//	@Tags			tags
+//	@Accept			json
+//	@Produce		json
+//	@Param			slug	path	string	true	"Tag slug"
+//	@Success		204
+//	@Failure		403	{object}	models.ErrorResponse
+//	@Failure		404	{object}	models.ErrorResponse
+//	@Failure		500	{object}	models.ErrorResponse
+//	@Router			/tags/{slug} [delete]
+func (handler *TagHandler) DeleteTagHandler(c *gin.Context) {
+	tag, ok := bindTag(c, taxonomy.New(handler.findTags()))
+	if !ok {
+		return
+	}
+
+	if _, err := handler.collection.DeleteOne(handler.ctx, bson.D{{Key: "_id", Value: tag.Slug}}); err != nil {
+		log.Panic().Msg("Error deleting tag from MongoDB")
+		return
+	}
+	handler.reparentTags(tag.Slug, tag.Parent)
+	handler.recipes.TagsChanged()
+
+	c.Status(http.StatusNoContent)
+}
+
+func (handler *TagHandler) findTags() []models.Tag {
+	return findTags(handler.ctx, handler.collection)
+}
+
+// reparentTags moves the children of one tag to another, or to the top level when to is empty.
+func (handler *TagHandler) reparentTags(from, to string) {
+	filter := bson.D{{Key: "parent", Value: from}}
+	update := bson.D{{Key: "$set", Value: bson.D{{Key: "parent", Value: to}}}}
+	if to == "" {
+		update = bson.D{{Key: "$unset", Value: bson.D{{Key: "parent", Value: ""}}}}
+	}
+	if _, err := handler.collection.UpdateMany(handler.ctx, filter, update); err != nil {
+		log.Panic().Msg("Error updating tags in MongoDB")
+	}
+}
+
+// rewriteRecipeTags refreshes the taxonomy used for recipes and replaces the given
+// tags with slug on every recipe.
+func (handler *TagHandler) rewriteRecipeTags(from []string, slug string, editor string) {
+	handler.recipes.TagsChanged()
+	handler.recipes.RetagRecipes(from, slug, editor)
+}
+
+// newTag validates tag input. current is the slug of the tag being updated, if any.
+func newTag(input models.AddUpdateTag, tags *taxonomy.Taxonomy, current string) (models.Tag, error) {
+	slug := input.Slug
+	if slug == "" {
+		slug = input.Name
+	}
+	tag := models.Tag{
+		Slug:     taxonomy.Slugify(slug),
+		Name:     input.Name,
+		Synonyms: appendSynonyms(nil, taxonomy.Slugify(slug), input.Synonyms...),
+		Parent:   taxonomy.Slugify(input.Parent),
+	}
+	if tag.Slug == "" {
+		return tag, fmt.Errorf("Tag slug must contain letters or digits")
+	}
+
+	for _, synonym := range tag.Synonyms {
+		if owner := tags.Canonical(synonym); tagExists(tags, owner) && owner != current {
+			return tag, fmt.Errorf("Synonym %s already belongs to tag %s", synonym, owner)
+		}
+	}
+	if tag.Parent != "" {
+		if !tagExists(tags, tag.Parent) {
+			return tag, fmt.Errorf("Parent tag not found: %s", tag.Parent)
+		}
+		if tag.Parent == tag.Slug || (current != "" && tags.IsAncestor(current, tag.Parent)) {
+			return tag, fmt.Errorf("Tag %s cannot be its own ancestor", tag.Slug)
+		}
+	}
+	return tag, nil
+}
+
+// bindTag loads the managed tag named by the slug path parameter.
+func bindTag(c *gin.Context, tags *taxonomy.Taxonomy) (models.Tag, bool) {
+	slug := c.Param("slug")
+	tag, ok := tags.Get(slug)
+	if !ok {
+		c.JSON(http.StatusNotFound, models.ErrorResponse{
+			Code:    http.StatusNotFound,
+			Message: fmt.Sprintf("Tag not found: %s", slug),
+		})
+	}
+	return tag, ok
+}
+
+func tagExists(tags *taxonomy.Taxonomy, slug string) bool {
+	_, ok := tags.Get(slug)
+	return ok
+}
+
+// appendSynonyms adds slugified synonyms, skipping the tag's own slug and duplicates.
+func appendSynonyms(synonyms []string, slug string, add ...string) []string {
+	seen := map[string]bool{slug: true}
+	result := make([]string, 0, len(synonyms)+len(add))
+	for _, synonym := range append(synonyms, add...) {
+		synonym = taxonomy.Slugify(synonym)
+		if synonym != "" && !seen[synonym] {
+			seen[synonym] = true
+			result = append(result, synonym)
+		}
+	}
+	return result
+}
+
+func findTags(ctx context.Context, collection *mongo.Collection) []models.Tag {
+	cursor, err := collection.Find(ctx, bson.D{})
+	if err != nil {
+		log.Panic().Msg("Error fetching tags from MongoDB")
+	}
+	tags := make([]models.Tag, 0)
+	if err := cursor.All(ctx, &tags); err != nil {
+		log.Panic().Msg("Error decoding tags from MongoDB")
+	}
+	return tags
+}
+
+// RetagRecipes replaces the given tags with slug on every recipe, without duplicates.
+// Each changed recipe gets a new version edited by editor.
+func (handler *RecipeHandler) RetagRecipes(from []string, slug string, editor string) {
+	if len(from) == 0 {
+		return
+	}
+
+	retagged := 0
+	for _, recipe := range handler.findRecipes(bson.D{{Key: "tags", Value: bson.D{{Key: "$in", Value: from}}}}) {
+		// A recipe edited in the meantime is retagged again from its new version.
+		for attempt := 0; attempt < 3; attempt++ {
+			tags := retag(recipe.Tags, from, slug)
+			if slices.Equal(tags, recipe.Tags) {
+				break
+			}
+			if _, updated := handler.updateRecipeVersioned(recipe, bson.D{{Key: "tags", Value: tags}}, editor); updated {
+				retagged++
+				break
+			}
+			err := handler.collection.FindOne(handler.ctx, bson.D{{Key: "_id", Value: recipe.ID}}).Decode(&recipe)
+			if err == mongo.ErrNoDocuments {
+				break
+			} else if err != nil {
+				log.Panic().Msg("Error fetching recipe from MongoDB")
+			}
+		}
+	}
+	if retagged > 0 {
+		handler.RecipesChanged()
+	}
+}
+
+func retag(tags []string, from []string, slug string) []string {
+	retagged := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		if slices.Contains(from, tag) {
+			tag = slug
+		}
+		if !slices.Contains(retagged, tag) {
+			retagged = append(retagged, tag)
+		}
+	}
+	return retagged
+}
@@ -0,0 +1,180 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mahesh-yadav/go-recipes-api/locale"
+	"github.com/mahesh-yadav/go-recipes-api/models"
+	"github.com/mahesh-yadav/go-recipes-api/steps"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// UpdateTranslationHandler godoc
+//
+//	@Summary		Add or update a translation
+//	@Description	Set the name, ingredients and instructions of a recipe in a locale other than its default one
+//	@Tags			translations
+//	@Accept			json
+//	@Produce		json
+//	@Param			id			path		string						true	"Recipe ID"
+//	@Param			locale		path		string						true	"Locale"	Enums(en, es, de)
+//	@Param			translation	body		models.AddUpdateTranslation	true	"Translation"
+//	@Success		200			{object}	models.ViewRecipe
+//	@Failure		400			{object}	models.ErrorResponse
+//	@Failure		403			{object}	models.ErrorResponse
+//	@Failure		404			{object}	models.ErrorResponse
+//	@Failure		409			{object}	models.ErrorResponse
+//	@Failure		500			{object}	models.ErrorResponse
+//	@Router			/recipes/{id}/translations/{locale} [put]
+func (handler *RecipeHandler) UpdateTranslationHandler(c *gin.Context) {
+	var translation models.AddUpdateTranslation
+	if err := c.ShouldBindJSON(&translation); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Invalid translation data",
+		})
+		return
+	}
+
+	recipe, code, ok := handler.bindTranslation(c)
+	if !ok {
+		return
+	}
+
+	instructions := steps.Structure(translation.Instructions)
+	if err := validateSteps(instructions, len(translation.Ingredients), recipe.Images); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Code:    http.StatusBadRequest,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	fields := bson.D{{Key: "translations." + code, Value: models.RecipeTranslation{
+		Name:         translation.Name,
+		Ingredients:  translation.Ingredients,
+		Instructions: instructions,
+	}}}
+	if _, updated := handler.updateRecipeVersioned(recipe, fields, currentUsername(c)); !updated {
+		recipeConflict(c)
+		return
+	}
+
+	handler.InvalidateCache()
+	updated, _ := handler.findRecipe(recipe.ID)
+	updated.Localize(code)
+	updated.HideCollaborators(currentUsername(c))
+	c.Header("Content-Language", updated.Locale)
+	c.JSON(http.StatusOK, updated)
+}
+
+// DeleteTranslationHandler godoc
+//
+//	@Summary		Delete a translation
+//	@Description	Remove the translation of a recipe for a locale. The previous content is kept in the recipe's version history.
+//	@Tags			translations
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path	string	true	"Recipe ID"
+//	@Param			locale	path	string	true	"Locale"	Enums(en, es, de)
+//	@Success		204
+//	@Failure		400	{object}	models.ErrorResponse
+//	@Failure		403	{object}	models.ErrorResponse
+//	@Failure		404	{object}	models.ErrorResponse
+//	@Failure		409	{object}	models.ErrorResponse
+//	@Failure		500	{object}	models.ErrorResponse
+//	@Router			/recipes/{id}/translations/{locale} [delete]
+func (handler *RecipeHandler) DeleteTranslationHandler(c *gin.Context) {
+	recipe, code, ok := handler.bindTranslation(c)
+	if !ok {
+		return
+	}
+	if _, found := recipe.Translations[code]; !found {
+		c.JSON(http.StatusNotFound, models.ErrorResponse{
+			Code:    http.StatusNotFound,
+			Message: fmt.Sprintf("Translation not found for locale: %s", code),
+		})
+		return
+	}
+
+	// The remaining translations replace the map so the change goes through the
+	// version history like any other edit.
+	translations := make(map[string]models.RecipeTranslation, len(recipe.Translations))
+	for other, translation := range recipe.Translations {
+		if other != code {
+			translations[other] = translation
+		}
+	}
+	fields := bson.D{{Key: "translations", Value: translations}}
+	if _, updated := handler.updateRecipeVersioned(recipe, fields, currentUsername(c)); !updated {
+		recipeConflict(c)
+		return
+	}
+
+	handler.InvalidateCache()
+	c.Status(http.StatusNoContent)
+}
+
+// bindTranslation loads the recipe and checks that the locale path parameter names
+// a supported locale other than the recipe's default one.
+func (handler *RecipeHandler) bindTranslation(c *gin.Context) (models.ViewRecipe, string, bool) {
+	code := strings.ToLower(c.Param("locale"))
+	if !locale.IsSupported(code) {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Code:    http.StatusBadRequest,
+			Message: fmt.Sprintf("Unsupported locale %s, expected one of %s", code, strings.Join(locale.Supported, ", ")),
+		})
+		return models.ViewRecipe{}, "", false
+	}
+
+	recipe, ok := handler.bindRecipe(c)
+	if !ok || !handler.requireEditor(c, recipe) {
+		return recipe, "", false
+	}
+	if code == recipe.Locales()[0] {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Code:    http.StatusBadRequest,
+			Message: fmt.Sprintf("%s is the default locale of this recipe, update the recipe instead", code),
+		})
+		return recipe, "", false
+	}
+	return recipe, code, true
+}
+
+// localizeRecipe translates the recipe for the request's Accept-Language header.
+func localizeRecipe(c *gin.Context, recipe *models.ViewRecipe) {
+	code, _ := locale.Negotiate(c.GetHeader("Accept-Language"), recipe.Locales())
+	recipe.Localize(code)
+	c.Header("Content-Language", recipe.Locale)
+}
+
+// localizeRecipes translates each recipe for the request's Accept-Language header.
+// Content-Language lists every locale used, since recipes without a matching
+// translation fall back to their default locale.
+func localizeRecipes(c *gin.Context, recipes []models.ViewRecipe) {
+	acceptLanguage := c.GetHeader("Accept-Language")
+	used := make(map[string]bool)
+	for i := range recipes {
+		code, _ := locale.Negotiate(acceptLanguage, recipes[i].Locales())
+		recipes[i].Localize(code)
+		used[recipes[i].Locale] = true
+	}
+
+	if len(used) == 0 {
+		code, ok := locale.Negotiate(acceptLanguage, locale.Supported)
+		if !ok {
+			code = locale.Default
+		}
+		used[code] = true
+	}
+	codes := make([]string, 0, len(used))
+	for code := range used {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	c.Header("Content-Language", strings.Join(codes, ", "))
+}
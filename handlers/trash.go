@@ -0,0 +1,122 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mahesh-yadav/go-recipes-api/models"
+	"github.com/rs/zerolog/log"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+// ListTrashHandler godoc
+//
+//	@Summary		List deleted recipes
+//	@Description	Get the recipes in the trash. Authors see the recipes they own or deleted, admins see all of them.
+//	@Tags			recipes
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{object}	models.ListRecipes
+//	@Failure		500	{object}	models.ErrorResponse
+//	@Router			/recipes/trash [get]
+func (handler *RecipeHandler) ListTrashHandler(c *gin.Context) {
+	filter := bson.D{{Key: "deleted_at", Value: bson.D{{Key: "$exists", Value: true}}}}
+
+	username := currentUsername(c)
+	if !isAdmin(handler.config, username) {
+		filter = append(filter, bson.E{Key: "$or", Value: bson.A{
+			bson.D{{Key: "author", Value: username}},
+			bson.D{{Key: "deleted_by", Value: username}},
+		}})
+	}
+
+	recipes := handler.findRecipes(filter)
+	hideCollaborators(c, recipes)
+	c.JSON(http.StatusOK, models.ListRecipes{
+		Count: len(recipes),
+		Data:  recipes,
+	})
+}
+
+// RestoreRecipeHandler godoc
+//
+//	@Summary		Restore a deleted recipe
+//	@Description	Move a recipe out of the trash. Only its author, the user who deleted it or an admin can restore it.
+//	@Tags			recipes
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		string	true	"Recipe ID"
+//	@Success		200	{object}	models.ViewRecipe
+//	@Failure		400	{object}	models.ErrorResponse
+//	@Failure		403	{object}	models.ErrorResponse
+//	@Failure		404	{object}	models.ErrorResponse
+//	@Failure		500	{object}	models.ErrorResponse
+//	@Router			/recipes/{id}/restore [post]
+func (handler *RecipeHandler) RestoreRecipeHandler(c *gin.Context) {
+	id := c.Param("id")
+	objectID, err := bson.ObjectIDFromHex(id)
+	if err != nil {
+		log.Error().Str("ID", id).Msg("Invalid Recipe ID")
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Invalid Recipe ID",
+		})
+		return
+	}
+
+	var recipe models.ViewRecipe
+	filter := bson.D{
+		{Key: "_id", Value: objectID},
+		{Key: "deleted_at", Value: bson.D{{Key: "$exists", Value: true}}},
+	}
+	err = handler.collection.FindOne(handler.ctx, filter).Decode(&recipe)
+	if err == mongo.ErrNoDocuments {
+		c.JSON(http.StatusNotFound, models.ErrorResponse{
+			Code:    http.StatusNotFound,
+			Message: fmt.Sprintf("Deleted recipe not found with ID: %s", id),
+		})
+		return
+	} else if err != nil {
+		log.Panic().Msg("Error fetching recipe from MongoDB")
+	}
+
+	username := currentUsername(c)
+	if recipe.Author != username && recipe.DeletedBy != username && !isAdmin(handler.config, username) {
+		c.JSON(http.StatusForbidden, models.ErrorResponse{
+			Code:    http.StatusForbidden,
+			Message: "Only the author can restore this recipe",
+		})
+		return
+	}
+
+	update := bson.D{{Key: "$unset", Value: bson.D{
+		{Key: "deleted_at", Value: ""},
+		{Key: "deleted_by", Value: ""},
+	}}}
+	result, err := handler.collection.UpdateOne(handler.ctx, filter, update)
+	if err != nil {
+		log.Panic().Msg("Error restoring recipe in MongoDB")
+		return
+	}
+	if result.ModifiedCount > 0 {
+		handler.countFork(recipe.ForkedFrom, 1)
+	}
+
+	handler.InvalidateCache()
+	handler.indexRecipe(objectID)
+	restored, _ := handler.findRecipe(objectID)
+	restored.HideCollaborators(currentUsername(c))
+	c.JSON(http.StatusOK, restored)
+}
+
+// PurgeRecipeData removes the images and version history of a recipe that was purged from the trash.
+func (handler *RecipeHandler) PurgeRecipeData(recipe models.ViewRecipe) {
+	handler.deleteRecipeImages(recipe)
+
+	filter := bson.D{{Key: "recipe_id", Value: recipe.ID}}
+	if _, err := handler.versionCollection.DeleteMany(handler.ctx, filter); err != nil {
+		log.Error().Err(err).Str("ID", recipe.ID.Hex()).Msg("Error deleting recipe versions from MongoDB")
+	}
+}
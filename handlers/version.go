@@ -0,0 +1,435 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mahesh-yadav/go-recipes-api/models"
+	"github.com/rs/zerolog/log"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// ListRecipeVersionsHandler godoc
+//
+//	@Summary		List recipe versions
+//	@Description	Get the previous versions of a recipe, newest first
+//	@Tags			versions
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		string	true	"Recipe ID"
+//	@Success		200	{object}	models.ListRecipeVersions
+//	@Failure		400	{object}	models.ErrorResponse
+//	@Failure		404	{object}	models.ErrorResponse
+//	@Failure		500	{object}	models.ErrorResponse
+//	@Router			/recipes/{id}/versions [get]
+func (handler *RecipeHandler) ListRecipeVersionsHandler(c *gin.Context) {
+	recipe, ok := handler.bindRecipe(c)
+	if !ok {
+		return
+	}
+
+	filter := bson.D{{Key: "recipe_id", Value: recipe.ID}}
+	opts := options.Find().SetSort(bson.D{{Key: "version", Value: -1}})
+	cursor, err := handler.versionCollection.Find(handler.ctx, filter, opts)
+	if err != nil {
+		log.Panic().Msg("Error fetching recipe versions from MongoDB")
+		return
+	}
+	defer cursor.Close(handler.ctx)
+
+	versions := make([]models.RecipeVersion, 0)
+	if err := cursor.All(handler.ctx, &versions); err != nil {
+		log.Panic().Msg("Error decoding recipe versions from MongoDB")
+		return
+	}
+
+	for i := range versions {
+		hideVersionCollaborators(c, recipe, &versions[i])
+	}
+	c.JSON(http.StatusOK, models.ListRecipeVersions{
+		Count: len(versions),
+		Data:  versions,
+	})
+}
+
+// GetRecipeVersionHandler godoc
+//
+//	@Summary		Get a recipe version
+//	@Description	Get a recipe as it was at the given version
+//	@Tags			versions
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		string	true	"Recipe ID"
+//	@Param			v	path		int		true	"Version"
+//	@Success		200	{object}	models.RecipeVersion
+//	@Failure		400	{object}	models.ErrorResponse
+//	@Failure		404	{object}	models.ErrorResponse
+//	@Failure		500	{object}	models.ErrorResponse
+//	@Router			/recipes/{id}/versions/{v} [get]
+func (handler *RecipeHandler) GetRecipeVersionHandler(c *gin.Context) {
+	recipe, ok := handler.bindRecipe(c)
+	if !ok {
+		return
+	}
+
+	version, ok := handler.bindVersion(c, recipe)
+	if !ok {
+		return
+	}
+
+	hideVersionCollaborators(c, recipe, &version)
+	c.JSON(http.StatusOK, version)
+}
+
+// DiffRecipeVersionsHandler godoc
+//
+//	@Summary		Compare recipe versions
+//	@Description	Get the fields that changed between two versions of a recipe
+//	@Tags			versions
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string	true	"Recipe ID"
+//	@Param			from	query		int		true	"Version to compare from"
+//	@Param			to		query		int		false	"Version to compare to, defaults to the current version"
+//	@Success		200		{object}	models.RecipeDiff
+//	@Failure		400		{object}	models.ErrorResponse
+//	@Failure		404		{object}	models.ErrorResponse
+//	@Failure		500		{object}	models.ErrorResponse
+//	@Router			/recipes/{id}/versions/diff [get]
+func (handler *RecipeHandler) DiffRecipeVersionsHandler(c *gin.Context) {
+	recipe, ok := handler.bindRecipe(c)
+	if !ok {
+		return
+	}
+
+	var diffParams models.RecipeDiffParams
+	if err := c.ShouldBindQuery(&diffParams); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Invalid diff parameters",
+		})
+		return
+	}
+	if diffParams.To == 0 {
+		diffParams.To = currentVersion(recipe)
+	}
+
+	from, found := handler.findRecipeVersion(recipe, diffParams.From)
+	if !found {
+		versionNotFound(c, diffParams.From)
+		return
+	}
+	to, found := handler.findRecipeVersion(recipe, diffParams.To)
+	if !found {
+		versionNotFound(c, diffParams.To)
+		return
+	}
+
+	hideVersionCollaborators(c, recipe, &from, &to)
+	c.JSON(http.StatusOK, models.RecipeDiff{
+		RecipeID: recipe.ID,
+		From:     diffParams.From,
+		To:       diffParams.To,
+		Changes:  diffRecipes(from.Recipe, to.Recipe),
+	})
+}
+
+// RestoreRecipeVersionHandler godoc
+//
+//	@Summary		Restore a recipe version
+//	@Description	Make an earlier version the current content of a recipe. The replaced content is kept as a version too. Steps lose references to images deleted since that version.
+//	@Tags			versions
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		string	true	"Recipe ID"
+//	@Param			v	path		int		true	"Version"
+//	@Success		200	{object}	models.ViewRecipe
+//	@Failure		400	{object}	models.ErrorResponse
+//	@Failure		403	{object}	models.ErrorResponse
+//	@Failure		404	{object}	models.ErrorResponse
+//	@Failure		409	{object}	models.ErrorResponse
+//	@Failure		500	{object}	models.ErrorResponse
+//	@Router			/recipes/{id}/versions/{v}/restore [post]
+func (handler *RecipeHandler) RestoreRecipeVersionHandler(c *gin.Context) {
+	recipe, ok := handler.bindRecipe(c)
+	if !ok || !handler.requireEditor(c, recipe) {
+		return
+	}
+
+	version, ok := handler.bindVersion(c, recipe)
+	if !ok {
+		return
+	}
+	if version.Version == currentVersion(recipe) {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Code:    http.StatusBadRequest,
+			Message: fmt.Sprintf("Version %d is already the current version", version.Version),
+		})
+		return
+	}
+
+	restored := version.Recipe
+	if restored.Translations == nil {
+		// Translations are set per locale, which fails on a null document.
+		restored.Translations = map[string]models.RecipeTranslation{}
+	}
+	restored.Instructions = withoutStaleImages(restored.Instructions, recipe.Images)
+	if err := validateSteps(restored.Instructions, len(restored.Ingredients), recipe.Images); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Code:    http.StatusBadRequest,
+			Message: err.Error(),
+		})
+		return
+	}
+	fields := recipeContentFields(recipeContent(restored))
+	fields = append(fields,
+		bson.E{Key: "allergens", Value: restored.Allergens},
+		bson.E{Key: "diets", Value: restored.Diets},
+		bson.E{Key: "labels_overridden", Value: restored.LabelsOverridden},
+		bson.E{Key: "translations", Value: restored.Translations},
+	)
+	// Translations are keyed against the default locale, so it is restored with them.
+	// Visibility is left as it is: restoring content must not expose a recipe.
+	if restored.DefaultLocale != "" {
+		fields = append(fields, bson.E{Key: "default_locale", Value: restored.DefaultLocale})
+	}
+	if _, updated := handler.updateRecipeVersioned(recipe, fields, currentUsername(c)); !updated {
+		recipeConflict(c)
+		return
+	}
+
+	handler.InvalidateCache()
+	handler.indexRecipe(recipe.ID)
+	current, _ := handler.findRecipe(recipe.ID)
+	current.HideCollaborators(currentUsername(c))
+	c.JSON(http.StatusOK, current)
+}
+
+// hideVersionCollaborators strips the collaborators from the versions unless the
+// current user is a member of the recipe now, whatever they were when it was saved.
+func hideVersionCollaborators(c *gin.Context, recipe models.ViewRecipe, versions ...*models.RecipeVersion) {
+	if recipe.IsMember(currentUsername(c)) {
+		return
+	}
+	for _, version := range versions {
+		version.Recipe.Collaborators = nil
+	}
+}
+
+// withoutStaleImages drops step references to images deleted since the steps were
+// written, as their files are gone.
+func withoutStaleImages(instructions models.Instructions, images []models.RecipeImage) models.Instructions {
+	imageIDs := make(map[string]bool, len(images))
+	for _, image := range images {
+		imageIDs[image.ID] = true
+	}
+	cleaned := make(models.Instructions, len(instructions))
+	for i, step := range instructions {
+		if !imageIDs[step.ImageID] {
+			step.ImageID = ""
+		}
+		cleaned[i] = step
+	}
+	return cleaned
+}
+
+// updateRecipeVersioned snapshots the existing recipe into the version history, then
+// applies fields on top of it and bumps its version. It reports false, leaving the
+// recipe untouched, when someone else changed the recipe in the meantime.
+func (handler *RecipeHandler) updateRecipeVersioned(existing models.ViewRecipe, fields bson.D, editor string) (*mongo.UpdateResult, bool) {
+	version := currentVersion(existing)
+	snapshot := models.RecipeVersion{
+		RecipeID: existing.ID,
+		Version:  version,
+		Editor:   editor,
+		EditedAt: time.Now(),
+		Recipe:   existing,
+	}
+	snapshot.Recipe.Version = version
+
+	inserted, err := handler.versionCollection.InsertOne(handler.ctx, snapshot)
+	if err != nil {
+		log.Panic().Msg("Error saving recipe version in MongoDB")
+	}
+
+	// Recipes created before versioning have no version field.
+	filter := bson.D{{Key: "_id", Value: existing.ID}}
+	if existing.Version == 0 {
+		filter = append(filter, bson.E{Key: "version", Value: bson.D{{Key: "$in", Value: bson.A{0, nil}}}})
+	} else {
+		filter = append(filter, bson.E{Key: "version", Value: existing.Version})
+	}
+	fields = append(fields,
+		bson.E{Key: "version", Value: version + 1},
+		bson.E{Key: "updated_at", Value: snapshot.EditedAt},
+		bson.E{Key: "updated_by", Value: editor},
+	)
+
+	result, err := handler.collection.UpdateOne(handler.ctx, filter, bson.D{{Key: "$set", Value: fields}})
+	if err != nil {
+		log.Panic().Msg("Error updating recipe in MongoDB")
+	}
+	if result.MatchedCount == 0 {
+		handler.versionCollection.DeleteOne(handler.ctx, bson.D{{Key: "_id", Value: inserted.InsertedID}})
+		return result, false
+	}
+	return result, true
+}
+
+func (handler *RecipeHandler) findRecipeVersion(recipe models.ViewRecipe, version int) (models.RecipeVersion, bool) {
+	if version == currentVersion(recipe) {
+		current := recipe
+		current.Version = version
+		return models.RecipeVersion{RecipeID: recipe.ID, Version: version, Recipe: current}, true
+	}
+
+	var recipeVersion models.RecipeVersion
+	filter := bson.D{{Key: "recipe_id", Value: recipe.ID}, {Key: "version", Value: version}}
+	err := handler.versionCollection.FindOne(handler.ctx, filter).Decode(&recipeVersion)
+	if err == mongo.ErrNoDocuments {
+		return recipeVersion, false
+	} else if err != nil {
+		log.Panic().Msg("Error fetching recipe version from MongoDB")
+	}
+	return recipeVersion, true
+}
+
+// bindRecipe loads the recipe named by the id path parameter, responding with an
+// error when the id is invalid or the recipe does not exist or is hidden from the user.
+func (handler *RecipeHandler) bindRecipe(c *gin.Context) (models.ViewRecipe, bool) {
+	id := c.Param("id")
+
+	objectID, err := bson.ObjectIDFromHex(id)
+	if err != nil {
+		log.Error().Str("ID", id).Msg("Invalid Recipe ID")
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Invalid Recipe ID",
+		})
+		return models.ViewRecipe{}, false
+	}
+
+	recipe, found := handler.findRecipe(objectID)
+	username := currentUsername(c)
+	if !found || !(recipe.CanView(username) || isAdmin(handler.config, username)) {
+		c.JSON(http.StatusNotFound, models.ErrorResponse{
+			Code:    http.StatusNotFound,
+			Message: fmt.Sprintf("Recipe not found with ID: %s", id),
+		})
+		return recipe, false
+	}
+	return recipe, true
+}
+
+func (handler *RecipeHandler) bindVersion(c *gin.Context, recipe models.ViewRecipe) (models.RecipeVersion, bool) {
+	version, err := strconv.Atoi(c.Param("v"))
+	if err != nil || version < 1 {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Invalid version",
+		})
+		return models.RecipeVersion{}, false
+	}
+
+	recipeVersion, found := handler.findRecipeVersion(recipe, version)
+	if !found {
+		versionNotFound(c, version)
+		return recipeVersion, false
+	}
+	return recipeVersion, true
+}
+
+func versionNotFound(c *gin.Context, version int) {
+	c.JSON(http.StatusNotFound, models.ErrorResponse{
+		Code:    http.StatusNotFound,
+		Message: fmt.Sprintf("Version not found: %d", version),
+	})
+}
+
+func recipeConflict(c *gin.Context) {
+	c.JSON(http.StatusConflict, models.ErrorResponse{
+		Code:    http.StatusConflict,
+		Message: "Recipe was changed by someone else, reload it and try again",
+	})
+}
+
+// currentVersion treats recipes created before versioning as version 1.
+func currentVersion(recipe models.ViewRecipe) int {
+	return max(recipe.Version, 1)
+}
+
+// recipeContent copies the editable content of a stored recipe.
+func recipeContent(recipe models.ViewRecipe) models.Recipe {
+	return models.Recipe{
+		Name:             recipe.Name,
+		Tags:             recipe.Tags,
+		Ingredients:      recipe.Ingredients,
+		Instructions:     recipe.Instructions,
+		Servings:         recipe.Servings,
+		Nutrition:        recipe.Nutrition,
+		PrepTime:         recipe.PrepTime,
+		CookTime:         recipe.CookTime,
+		TotalTime:        recipe.TotalTime,
+		TotalTimeMinutes: recipe.TotalTimeMinutes,
+		Difficulty:       recipe.Difficulty,
+		Cuisine:          recipe.Cuisine,
+		Course:           recipe.Course,
+		Equipment:        recipe.Equipment,
+		Yield:            recipe.Yield,
+		Attribution:      recipe.Attribution,
+		Visibility:       recipe.Visibility,
+		DefaultLocale:    recipe.DefaultLocale,
+		Translations:     recipe.Translations,
+	}
+}
+
+// diffRecipes compares the JSON representation of two recipes field by field.
+func diffRecipes(from, to models.ViewRecipe) []models.FieldChange {
+	fromFields, toFields := jsonFields(from), jsonFields(to)
+
+	names := make([]string, 0, len(fromFields))
+	for name := range fromFields {
+		names = append(names, name)
+	}
+	for name := range toFields {
+		if _, ok := fromFields[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	changes := make([]models.FieldChange, 0)
+	for _, name := range names {
+		if name == "id" || name == "version" {
+			continue
+		}
+		if !reflect.DeepEqual(fromFields[name], toFields[name]) {
+			changes = append(changes, models.FieldChange{
+				Field: name,
+				From:  fromFields[name],
+				To:    toFields[name],
+			})
+		}
+	}
+	return changes
+}
+
+func jsonFields(recipe models.ViewRecipe) map[string]any {
+	fields := make(map[string]any)
+	data, err := json.Marshal(recipe)
+	if err != nil {
+		log.Panic().Msg("Error marshalling recipe to JSON")
+	}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		log.Panic().Msg("Error unmarshalling recipe from JSON")
+	}
+	return fields
+}
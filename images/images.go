@@ -0,0 +1,138 @@
+package images
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	_ "image/gif"
+	"image/jpeg"
+	_ "image/png"
+	"net/http"
+)
+
+const (
+	maxPixels   = 40_000_000
+	jpegQuality = 85
+)
+
+var ErrUnsupportedType = errors.New("unsupported image type")
+
+// Sizes maps each thumbnail name to the maximum length of its longest side in pixels.
+var Sizes = map[string]int{
+	"thumbnail": 150,
+	"small":     320,
+	"medium":    640,
+	"large":     1280,
+}
+
+var extensions = map[string]string{
+	"image/jpeg": "jpg",
+	"image/png":  "png",
+	"image/gif":  "gif",
+}
+
+type Rendition struct {
+	Name   string
+	Data   []byte
+	Width  int
+	Height int
+}
+
+// DetectContentType sniffs the content type from the data itself rather than trusting
+// the client, and returns the matching file extension.
+func DetectContentType(data []byte) (string, string, error) {
+	contentType := http.DetectContentType(data)
+	extension, ok := extensions[contentType]
+	if !ok {
+		return contentType, "", fmt.Errorf("%w: %s", ErrUnsupportedType, contentType)
+	}
+	return contentType, extension, nil
+}
+
+// Thumbnails decodes an image and renders it as JPEG at every size in Sizes, keeping the
+// aspect ratio. Images are never scaled up.
+func Thumbnails(data []byte) ([]Rendition, image.Point, error) {
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, image.Point{}, err
+	}
+	if config.Width*config.Height > maxPixels {
+		return nil, image.Point{}, fmt.Errorf("image is too large: %dx%d", config.Width, config.Height)
+	}
+
+	source, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, image.Point{}, err
+	}
+	bounds := source.Bounds()
+
+	// Flatten onto white so transparent PNG and GIF images look right as JPEG.
+	flattened := image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	draw.Draw(flattened, flattened.Bounds(), &image.Uniform{C: color.White}, image.Point{}, draw.Src)
+	draw.Draw(flattened, flattened.Bounds(), source, bounds.Min, draw.Over)
+
+	renditions := make([]Rendition, 0, len(Sizes))
+	for name, maxSide := range Sizes {
+		width, height := fit(bounds.Dx(), bounds.Dy(), maxSide)
+		resized := resize(flattened, width, height)
+
+		var buffer bytes.Buffer
+		if err := jpeg.Encode(&buffer, resized, &jpeg.Options{Quality: jpegQuality}); err != nil {
+			return nil, image.Point{}, err
+		}
+		renditions = append(renditions, Rendition{Name: name, Data: buffer.Bytes(), Width: width, Height: height})
+	}
+	return renditions, image.Point{X: bounds.Dx(), Y: bounds.Dy()}, nil
+}
+
+func fit(width, height, maxSide int) (int, int) {
+	if width <= maxSide && height <= maxSide {
+		return width, height
+	}
+	if width >= height {
+		return maxSide, max(1, height*maxSide/width)
+	}
+	return max(1, width*maxSide/height), maxSide
+}
+
+// resize scales down with a box filter: every destination pixel is the average of the
+// source pixels it covers.
+func resize(source *image.RGBA, width, height int) *image.RGBA {
+	sourceWidth, sourceHeight := source.Bounds().Dx(), source.Bounds().Dy()
+	if width == sourceWidth && height == sourceHeight {
+		return source
+	}
+
+	destination := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		top := y * sourceHeight / height
+		bottom := max(top+1, (y+1)*sourceHeight/height)
+		for x := 0; x < width; x++ {
+			left := x * sourceWidth / width
+			right := max(left+1, (x+1)*sourceWidth/width)
+
+			var r, g, b, a, count int
+			for sy := top; sy < bottom; sy++ {
+				offset := sy*source.Stride + left*4
+				for sx := left; sx < right; sx++ {
+					r += int(source.Pix[offset])
+					g += int(source.Pix[offset+1])
+					b += int(source.Pix[offset+2])
+					a += int(source.Pix[offset+3])
+					offset += 4
+					count++
+				}
+			}
+
+			i := y*destination.Stride + x*4
+			destination.Pix[i] = uint8(r / count)
+			destination.Pix[i+1] = uint8(g / count)
+			destination.Pix[i+2] = uint8(b / count)
+			destination.Pix[i+3] = uint8(a / count)
+		}
+	}
+	return destination
+}
@@ -0,0 +1,49 @@
+package ingredients
+
+// Coverage is the result of matching a set of available items against a recipe's ingredients.
+type Coverage struct {
+	Matched int
+	Total   int
+	Missing []string
+}
+
+// Ratio returns the share of ingredients covered, between 0 and 1.
+func (c Coverage) Ratio() float64 {
+	if c.Total == 0 {
+		return 0
+	}
+	return float64(c.Matched) / float64(c.Total)
+}
+
+// Match checks every ingredient line against the available items. Lines that do not name
+// an ingredient, such as separators, are ignored.
+func Match(items []string, lines []string) Coverage {
+	itemTokens := make([][]string, 0, len(items))
+	for _, item := range items {
+		itemTokens = append(itemTokens, Tokens(item))
+	}
+
+	coverage := Coverage{Missing: make([]string, 0)}
+	for _, line := range lines {
+		name := Parse(line).Name
+		nameTokens := tokenSet(name)
+		if len(nameTokens) == 0 {
+			continue
+		}
+
+		coverage.Total++
+		covered := false
+		for _, tokens := range itemTokens {
+			if covers(tokens, nameTokens) {
+				covered = true
+				break
+			}
+		}
+		if covered {
+			coverage.Matched++
+		} else {
+			coverage.Missing = append(coverage.Missing, name)
+		}
+	}
+	return coverage
+}
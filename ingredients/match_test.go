@@ -0,0 +1,60 @@
+package ingredients
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCovers(t *testing.T) {
+	tests := []struct {
+		item string
+		line string
+		want bool
+	}{
+		{item: "olive oil", line: "2 tbsp extra-virgin olive oil", want: true},
+		{item: "Egg", line: "3 large eggs, beaten", want: true},
+		{item: "tomatoes", line: "1 tomato", want: true},
+		{item: "flour", line: "1 1/2 cups all-purpose flour", want: true},
+		{item: "milk", line: "1 can coconut milk", want: true},
+		{item: "coconut milk", line: "1 cup milk", want: false},
+		{item: "oil", line: "1 cup olive oil", want: true},
+		{item: "garlic", line: "2 cloves garlic", want: true},
+		{item: "sugar", line: "1 cup flour", want: false},
+		{item: "fresh", line: "1 cup fresh basil", want: false},
+		{item: "", line: "1 cup flour", want: false},
+	}
+	for _, test := range tests {
+		if got := Covers(test.item, test.line); got != test.want {
+			t.Errorf("Covers(%q, %q) = %v, want %v", test.item, test.line, got, test.want)
+		}
+	}
+}
+
+func TestMatch(t *testing.T) {
+	lines := []string{
+		"2 cups all-purpose flour",
+		"3 large eggs",
+		"1 cup whole milk",
+		"---",
+		"1 tsp vanilla extract",
+	}
+	coverage := Match([]string{"egg", "flour", "milk"}, lines)
+	want := Coverage{Matched: 3, Total: 4, Missing: []string{"vanilla extract"}}
+	if !reflect.DeepEqual(coverage, want) {
+		t.Errorf("Match() = %+v, want %+v", coverage, want)
+	}
+	if got := coverage.Ratio(); got != 0.75 {
+		t.Errorf("Ratio() = %v, want 0.75", got)
+	}
+}
+
+func TestMatchNothing(t *testing.T) {
+	coverage := Match(nil, []string{"1 cup rice"})
+	want := Coverage{Matched: 0, Total: 1, Missing: []string{"rice"}}
+	if !reflect.DeepEqual(coverage, want) {
+		t.Errorf("Match() = %+v, want %+v", coverage, want)
+	}
+	if got := (Coverage{}).Ratio(); got != 0 {
+		t.Errorf("Ratio() of no ingredients = %v, want 0", got)
+	}
+}
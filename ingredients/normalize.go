@@ -0,0 +1,105 @@
+package ingredients
+
+import (
+	"strings"
+	"unicode"
+)
+
+// descriptors are preparation and size words that do not change what the ingredient is.
+var descriptors = map[string]bool{
+	"large": true, "medium": true, "small": true, "big": true, "extra-large": true,
+	"fresh": true, "freshly": true, "finely": true, "thinly": true, "roughly": true, "coarsely": true, "lightly": true,
+	"chopped": true, "diced": true, "minced": true, "sliced": true, "grated": true, "shredded": true, "crushed": true,
+	"peeled": true, "seeded": true, "halved": true, "cubed": true, "melted": true, "softened": true, "beaten": true,
+	"packed": true, "heaping": true, "level": true, "optional": true, "about": true, "approximately": true,
+	"good": true, "quality": true, "organic": true, "preferably": true,
+}
+
+// stopwords never identify an ingredient on their own.
+var stopwords = map[string]bool{
+	"a": true, "an": true, "and": true, "or": true, "of": true, "the": true, "to": true, "for": true,
+	"with": true, "in": true, "into": true, "plus": true, "more": true, "taste": true, "as": true, "needed": true,
+	"extra": true, "virgin": true,
+}
+
+var irregularPlurals = map[string]string{
+	"leaves": "leaf", "halves": "half", "loaves": "loaf", "knives": "knife",
+	"teeth": "tooth", "feet": "foot", "geese": "goose",
+}
+
+// Singular returns a best-effort singular form of an English noun. The result is only
+// meant to be compared with other normalized words, so it does not always read naturally.
+func Singular(word string) string {
+	if singular, ok := irregularPlurals[word]; ok {
+		return singular
+	}
+	switch {
+	case len(word) <= 3:
+		return word
+	case strings.HasSuffix(word, "ies"):
+		return strings.TrimSuffix(word, "ies") + "y"
+	case strings.HasSuffix(word, "oes"),
+		strings.HasSuffix(word, "ches"),
+		strings.HasSuffix(word, "shes"),
+		strings.HasSuffix(word, "sses"),
+		strings.HasSuffix(word, "xes"),
+		strings.HasSuffix(word, "zes"):
+		return strings.TrimSuffix(word, "es")
+	case strings.HasSuffix(word, "ss"),
+		strings.HasSuffix(word, "us"),
+		strings.HasSuffix(word, "is"):
+		return word
+	case strings.HasSuffix(word, "s"):
+		return strings.TrimSuffix(word, "s")
+	}
+	return word
+}
+
+// Tokens returns the normalized, singular words identifying an ingredient name, with
+// descriptors and stopwords removed. "Extra-virgin olive oils" becomes [olive oil].
+func Tokens(name string) []string {
+	words := strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return !unicode.IsLetter(r)
+	})
+
+	tokens := make([]string, 0, len(words))
+	for _, word := range words {
+		if descriptors[word] || stopwords[word] {
+			continue
+		}
+		tokens = append(tokens, Singular(word))
+	}
+	return tokens
+}
+
+// Normalize returns the canonical form of a pantry item or ingredient name.
+func Normalize(name string) string {
+	return strings.Join(Tokens(name), " ")
+}
+
+// Covers reports whether an item someone has, e.g. "olive oil", satisfies the ingredient
+// line, e.g. "2 tbsp extra-virgin olive oil". Every word of the item must appear in the
+// ingredient name, so generic items cover specific ones but not the other way around.
+func Covers(item string, line string) bool {
+	return covers(Tokens(item), tokenSet(Parse(line).Name))
+}
+
+func covers(itemTokens []string, nameTokens map[string]bool) bool {
+	if len(itemTokens) == 0 {
+		return false
+	}
+	for _, token := range itemTokens {
+		if !nameTokens[token] {
+			return false
+		}
+	}
+	return true
+}
+
+func tokenSet(name string) map[string]bool {
+	set := make(map[string]bool)
+	for _, token := range Tokens(name) {
+		set[token] = true
+	}
+	return set
+}
@@ -0,0 +1,175 @@
+package ingredients
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+type Ingredient struct {
+	Raw      string  `json:"raw"`
+	Quantity float64 `json:"quantity,omitempty"`
+	Unit     string  `json:"unit,omitempty"`
+	Name     string  `json:"name"`
+}
+
+var (
+	markdownLinkRegex = regexp.MustCompile(`\[([^\]]*)\]\([^)]*\)`)
+	htmlTagRegex      = regexp.MustCompile(`<[^>]*>`)
+	parenRegex        = regexp.MustCompile(`\([^)]*\)`)
+	spaceRegex        = regexp.MustCompile(`\s+`)
+	sizeParenRegex    = regexp.MustCompile(`\(\s*(\d+(?:\.\d+)?)\s*-?\s*([a-z]+)\.?\s*\)`)
+	sizeRegex         = regexp.MustCompile(`^(\d+(?:\.\d+)?)-?(oz|ounces?|g|grams?|ml|lbs?)\.?$`)
+)
+
+var unicodeFractions = map[rune]float64{
+	'¼': 0.25, '½': 0.5, '¾': 0.75,
+	'⅓': 1.0 / 3, '⅔': 2.0 / 3,
+	'⅛': 0.125, '⅜': 0.375, '⅝': 0.625, '⅞': 0.875,
+}
+
+var units = map[string]string{
+	"cup": "cup", "cups": "cup",
+	"tablespoon": "tbsp", "tablespoons": "tbsp", "tbsp": "tbsp", "tbsps": "tbsp", "tbs": "tbsp", "tbl": "tbsp",
+	"teaspoon": "tsp", "teaspoons": "tsp", "tsp": "tsp", "tsps": "tsp",
+	"ounce": "oz", "ounces": "oz", "oz": "oz",
+	"pound": "lb", "pounds": "lb", "lb": "lb", "lbs": "lb",
+	"gram": "g", "grams": "g", "g": "g", "gr": "g",
+	"kilogram": "kg", "kilograms": "kg", "kg": "kg",
+	"milliliter": "ml", "milliliters": "ml", "millilitre": "ml", "millilitres": "ml", "ml": "ml",
+	"liter": "l", "liters": "l", "litre": "l", "litres": "l",
+	"quart": "quart", "quarts": "quart", "qt": "quart",
+	"pint": "pint", "pints": "pint", "pt": "pint",
+	"pinch": "pinch", "pinches": "pinch",
+	"dash": "dash", "dashes": "dash",
+	"clove": "clove", "cloves": "clove",
+	"can": "can", "cans": "can",
+	"jar": "jar", "jars": "jar",
+	"package": "package", "packages": "package", "pkg": "package",
+	"slice": "slice", "slices": "slice",
+	"stick": "stick", "sticks": "stick",
+	"bunch": "bunch", "bunches": "bunch",
+	"sprig": "sprig", "sprigs": "sprig",
+	"head": "head", "heads": "head",
+	"handful": "handful", "handfuls": "handful",
+	"serving": "serving", "servings": "serving",
+}
+
+// Parse splits a free-text ingredient line such as "1 1/2 cups all-purpose flour, sifted"
+// into its quantity, canonical unit and cleaned ingredient name.
+func Parse(line string) Ingredient {
+	ingredient := Ingredient{Raw: strings.TrimSpace(line)}
+
+	text := markdownLinkRegex.ReplaceAllString(ingredient.Raw, "$1")
+	text = htmlTagRegex.ReplaceAllString(text, " ")
+	text = strings.ToLower(text)
+	text = sizeParenRegex.ReplaceAllString(text, "$1$2")
+
+	fields := strings.Fields(text)
+	quantity, fields := parseQuantity(fields)
+	ingredient.Quantity = quantity
+
+	if len(fields) > 1 {
+		if match := sizeRegex.FindStringSubmatch(fields[0]); match != nil {
+			size, _ := strconv.ParseFloat(match[1], 64)
+			if quantity == 0 {
+				quantity = 1
+			}
+			ingredient.Quantity = quantity * size
+			ingredient.Unit = units[match[2]]
+			fields = fields[1:]
+			if _, ok := units[strings.TrimSuffix(fields[0], ".")]; ok && len(fields) > 1 {
+				fields = fields[1:]
+			}
+		}
+	}
+	if ingredient.Unit == "" && len(fields) > 1 {
+		if unit, ok := units[strings.TrimSuffix(fields[0], ".")]; ok {
+			ingredient.Unit = unit
+			fields = fields[1:]
+		}
+	}
+	if len(fields) > 1 && fields[0] == "of" {
+		fields = fields[1:]
+	}
+
+	ingredient.Name = cleanName(strings.Join(fields, " "))
+	return ingredient
+}
+
+func parseQuantity(fields []string) (float64, []string) {
+	var total float64
+	i := 0
+	for ; i < len(fields); i++ {
+		value, ok := parseNumber(fields[i])
+		if !ok {
+			break
+		}
+		total += value
+		// Ranges such as "4 to 5" or "1-2" use the upper bound.
+		if i+2 < len(fields) && fields[i+1] == "to" {
+			if upper, ok := parseNumber(fields[i+2]); ok {
+				total = total - value + upper
+				i += 2
+			}
+		}
+	}
+	return total, fields[i:]
+}
+
+func parseNumber(s string) (float64, bool) {
+	if s == "" {
+		return 0, false
+	}
+	if low, high, found := strings.Cut(s, "-"); found && low != "" {
+		if _, ok := parseNumber(low); ok {
+			return parseNumber(high)
+		}
+		return 0, false
+	}
+
+	runes := []rune(s)
+	if fraction, ok := unicodeFractions[runes[len(runes)-1]]; ok {
+		if len(runes) == 1 {
+			return fraction, true
+		}
+		w, err := strconv.ParseFloat(string(runes[:len(runes)-1]), 64)
+		if err != nil {
+			return 0, false
+		}
+		return w + fraction, true
+	}
+	if numerator, denominator, found := strings.Cut(s, "/"); found {
+		n, err1 := strconv.ParseFloat(numerator, 64)
+		d, err2 := strconv.ParseFloat(denominator, 64)
+		if err1 != nil || err2 != nil || d == 0 {
+			return 0, false
+		}
+		return n / d, true
+	}
+	value, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, false
+	}
+	return value, true
+}
+
+func cleanName(text string) string {
+	text = parenRegex.ReplaceAllString(text, " ")
+	if before, _, found := strings.Cut(text, ","); found {
+		text = before
+	}
+	text = strings.TrimFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	words := make([]string, 0)
+	for _, word := range strings.Fields(text) {
+		if descriptors[word] {
+			continue
+		}
+		words = append(words, word)
+	}
+	return spaceRegex.ReplaceAllString(strings.Join(words, " "), " ")
+}
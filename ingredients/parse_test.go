@@ -0,0 +1,67 @@
+package ingredients
+
+import "testing"
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		line string
+		want Ingredient
+	}{
+		{line: "1 1/2 cups all-purpose flour, sifted", want: Ingredient{Quantity: 1.5, Unit: "cup", Name: "all-purpose flour"}},
+		{line: "2 tbsp. extra-virgin olive oil", want: Ingredient{Quantity: 2, Unit: "tbsp", Name: "extra-virgin olive oil"}},
+		{line: "½ teaspoon salt", want: Ingredient{Quantity: 0.5, Unit: "tsp", Name: "salt"}},
+		{line: "1½ cups milk", want: Ingredient{Quantity: 1.5, Unit: "cup", Name: "milk"}},
+		{line: "3 large eggs, beaten", want: Ingredient{Quantity: 3, Name: "eggs"}},
+		{line: "4 to 5 cloves garlic, minced", want: Ingredient{Quantity: 5, Unit: "clove", Name: "garlic"}},
+		{line: "1-2 medium onions", want: Ingredient{Quantity: 2, Name: "onions"}},
+		{line: "2 (14 oz) cans tomatoes", want: Ingredient{Quantity: 28, Unit: "oz", Name: "tomatoes"}},
+		{line: "1 14-ounce can coconut milk", want: Ingredient{Quantity: 14, Unit: "oz", Name: "coconut milk"}},
+		{line: "1 pinch of nutmeg", want: Ingredient{Quantity: 1, Unit: "pinch", Name: "nutmeg"}},
+		{line: "[Chocolate chips](https://example.com/chips) (optional)", want: Ingredient{Name: "chocolate chips"}},
+		{line: "<b>2</b> cups <i>sugar</i>", want: Ingredient{Quantity: 2, Unit: "cup", Name: "sugar"}},
+		{line: "Salt and pepper to taste", want: Ingredient{Name: "salt and pepper to taste"}},
+		{line: "---", want: Ingredient{}},
+	}
+	for _, test := range tests {
+		t.Run(test.line, func(t *testing.T) {
+			test.want.Raw = test.line
+			if got := Parse(test.line); got != test.want {
+				t.Errorf("Parse(%q) = %+v, want %+v", test.line, got, test.want)
+			}
+		})
+	}
+}
+
+func TestSingular(t *testing.T) {
+	tests := map[string]string{
+		"eggs":      "egg",
+		"tomatoes":  "tomato",
+		"berries":   "berry",
+		"peaches":   "peach",
+		"leaves":    "leaf",
+		"glass":     "glass",
+		"asparagus": "asparagus",
+		"peas":      "pea",
+		"oil":       "oil",
+	}
+	for word, want := range tests {
+		if got := Singular(word); got != want {
+			t.Errorf("Singular(%q) = %q, want %q", word, got, want)
+		}
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	tests := map[string]string{
+		"Extra-virgin olive oils":    "olive oil",
+		"Freshly grated Parmesan":    "parmesan",
+		"salt, to taste":             "salt",
+		"Large eggs":                 "egg",
+		"chopped fresh basil leaves": "basil leaf",
+	}
+	for name, want := range tests {
+		if got := Normalize(name); got != want {
+			t.Errorf("Normalize(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package isoduration
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Only day and time components are supported: years and months have no fixed length.
+var durationRegex = regexp.MustCompile(`^P(?:(\d+)W)?(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?(?:(\d+(?:\.\d+)?)S)?)?$`)
+
+var ErrInvalid = errors.New("invalid ISO-8601 duration")
+
+// Parse converts an ISO-8601 duration such as "PT1H30M" or "P1DT2H" to a time.Duration.
+func Parse(value string) (time.Duration, error) {
+	match := durationRegex.FindStringSubmatch(strings.ToUpper(value))
+	if match == nil || value == "P" || strings.HasSuffix(strings.ToUpper(value), "T") {
+		return 0, fmt.Errorf("%w: %q", ErrInvalid, value)
+	}
+
+	units := []time.Duration{7 * 24 * time.Hour, 24 * time.Hour, time.Hour, time.Minute}
+	var duration time.Duration
+	for i, unit := range units {
+		if match[i+1] == "" {
+			continue
+		}
+		amount, err := strconv.Atoi(match[i+1])
+		if err != nil {
+			return 0, fmt.Errorf("%w: %q", ErrInvalid, value)
+		}
+		duration += time.Duration(amount) * unit
+	}
+	if match[5] != "" {
+		seconds, err := strconv.ParseFloat(match[5], 64)
+		if err != nil {
+			return 0, fmt.Errorf("%w: %q", ErrInvalid, value)
+		}
+		duration += time.Duration(seconds * float64(time.Second))
+	}
+	return duration, nil
+}
+
+// Format converts a duration to its ISO-8601 form, e.g. 90 minutes becomes "PT1H30M".
+func Format(duration time.Duration) string {
+	if duration <= 0 {
+		return "PT0S"
+	}
+
+	var builder strings.Builder
+	builder.WriteString("P")
+	if days := duration / (24 * time.Hour); days > 0 {
+		fmt.Fprintf(&builder, "%dD", days)
+		duration -= days * 24 * time.Hour
+	}
+	if duration > 0 {
+		builder.WriteString("T")
+	}
+	if hours := duration / time.Hour; hours > 0 {
+		fmt.Fprintf(&builder, "%dH", hours)
+		duration -= hours * time.Hour
+	}
+	if minutes := duration / time.Minute; minutes > 0 {
+		fmt.Fprintf(&builder, "%dM", minutes)
+		duration -= minutes * time.Minute
+	}
+	if duration > 0 {
+		fmt.Fprintf(&builder, "%gS", duration.Seconds())
+	}
+	return builder.String()
+}
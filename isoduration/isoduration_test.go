@@ -0,0 +1,59 @@
+package isoduration
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		value string
+		want  time.Duration
+		err   error
+	}{
+		{value: "PT1H30M", want: 90 * time.Minute},
+		{value: "pt45m", want: 45 * time.Minute},
+		{value: "P1DT2H", want: 26 * time.Hour},
+		{value: "P2W", want: 14 * 24 * time.Hour},
+		{value: "PT1.5S", want: 1500 * time.Millisecond},
+		{value: "P0D", want: 0},
+		{value: "P", err: ErrInvalid},
+		{value: "PT", err: ErrInvalid},
+		{value: "P1DT", err: ErrInvalid},
+		{value: "P1M", err: ErrInvalid},
+		{value: "1 hour", err: ErrInvalid},
+		{value: "", err: ErrInvalid},
+	}
+	for _, test := range tests {
+		t.Run(test.value, func(t *testing.T) {
+			got, err := Parse(test.value)
+			if !errors.Is(err, test.err) {
+				t.Fatalf("Parse(%q) error = %v, want %v", test.value, err, test.err)
+			}
+			if got != test.want {
+				t.Errorf("Parse(%q) = %v, want %v", test.value, got, test.want)
+			}
+		})
+	}
+}
+
+func TestFormat(t *testing.T) {
+	tests := []struct {
+		duration time.Duration
+		want     string
+	}{
+		{duration: 0, want: "PT0S"},
+		{duration: -time.Minute, want: "PT0S"},
+		{duration: 90 * time.Minute, want: "PT1H30M"},
+		{duration: 26 * time.Hour, want: "P1DT2H"},
+		{duration: 48 * time.Hour, want: "P2D"},
+		{duration: 90 * time.Second, want: "PT1M30S"},
+		{duration: 1500 * time.Millisecond, want: "PT1.5S"},
+	}
+	for _, test := range tests {
+		if got := Format(test.duration); got != test.want {
+			t.Errorf("Format(%v) = %q, want %q", test.duration, got, test.want)
+		}
+	}
+}
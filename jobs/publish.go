@@ -0,0 +1,77 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"github.com/mahesh-yadav/go-recipes-api/models"
+	"github.com/rs/zerolog/log"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// PublishScheduledRecipes publishes every recipe whose scheduled time has passed
+// and returns the IDs of the recipes it published.
+func PublishScheduledRecipes(ctx context.Context, collection *mongo.Collection, now time.Time) ([]bson.ObjectID, error) {
+	filter := bson.D{{Key: "scheduled_at", Value: bson.D{{Key: "$lte", Value: now}}}}
+	cursor, err := collection.Find(ctx, filter, options.Find().SetProjection(bson.D{{Key: "_id", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	var recipes []struct {
+		ID bson.ObjectID `bson:"_id"`
+	}
+	if err := cursor.All(ctx, &recipes); err != nil {
+		return nil, err
+	}
+	if len(recipes) == 0 {
+		return nil, nil
+	}
+
+	ids := make([]bson.ObjectID, 0, len(recipes))
+	for _, recipe := range recipes {
+		ids = append(ids, recipe.ID)
+	}
+	filter = append(filter, bson.E{Key: "_id", Value: bson.D{{Key: "$in", Value: ids}}})
+	update := mongo.Pipeline{
+		{{Key: "$set", Value: bson.D{
+			{Key: "status", Value: models.StatusPublished},
+			{Key: "published_at", Value: bson.D{{Key: "$ifNull", Value: bson.A{"$published_at", "$scheduled_at"}}}},
+			{Key: "updated_at", Value: now},
+		}}},
+		{{Key: "$unset", Value: "scheduled_at"}},
+	}
+
+	if _, err := collection.UpdateMany(ctx, filter, update); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// RunScheduledPublisher publishes scheduled recipes every interval until ctx is done.
+// onPublished is called with the published IDs after a run that published at least
+// one recipe.
+func RunScheduledPublisher(ctx context.Context, collection *mongo.Collection, interval time.Duration, onPublished func([]bson.ObjectID)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			published, err := PublishScheduledRecipes(ctx, collection, now)
+			if err != nil {
+				log.Error().Err(err).Msg("Error publishing scheduled recipes")
+				continue
+			}
+			if len(published) > 0 {
+				log.Info().Int("count", len(published)).Msg("Published scheduled recipes")
+				if onPublished != nil {
+					onPublished(published)
+				}
+			}
+		}
+	}
+}
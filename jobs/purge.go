@@ -0,0 +1,69 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"github.com/mahesh-yadav/go-recipes-api/models"
+	"github.com/rs/zerolog/log"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+// PurgeDeletedRecipes permanently deletes recipes that were moved to the trash before
+// cutoff. onPurged is called for each deleted recipe so its related data can be removed.
+func PurgeDeletedRecipes(ctx context.Context, collection *mongo.Collection, cutoff time.Time, onPurged func(models.ViewRecipe)) (int, error) {
+	filter := bson.D{{Key: "deleted_at", Value: bson.D{{Key: "$lte", Value: cutoff}}}}
+	cursor, err := collection.Find(ctx, filter)
+	if err != nil {
+		return 0, err
+	}
+
+	recipes := make([]models.ViewRecipe, 0)
+	if err := cursor.All(ctx, &recipes); err != nil {
+		return 0, err
+	}
+
+	purged := 0
+	for _, recipe := range recipes {
+		result, err := collection.DeleteOne(ctx, bson.D{
+			{Key: "_id", Value: recipe.ID},
+			{Key: "deleted_at", Value: bson.D{{Key: "$lte", Value: cutoff}}},
+		})
+		if err != nil {
+			return purged, err
+		}
+		if result.DeletedCount == 0 {
+			// Restored since it was fetched.
+			continue
+		}
+		purged++
+		if onPurged != nil {
+			onPurged(recipe)
+		}
+	}
+	return purged, nil
+}
+
+// RunTrashPurger purges recipes that have been in the trash longer than retention,
+// checking every interval until ctx is done.
+func RunTrashPurger(ctx context.Context, collection *mongo.Collection, retention, interval time.Duration, onPurged func(models.ViewRecipe)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			purged, err := PurgeDeletedRecipes(ctx, collection, now.Add(-retention), onPurged)
+			if err != nil {
+				log.Error().Err(err).Msg("Error purging deleted recipes")
+				continue
+			}
+			if purged > 0 {
+				log.Info().Int("count", purged).Msg("Purged deleted recipes")
+			}
+		}
+	}
+}
@@ -0,0 +1,73 @@
+// Package locale picks the best content language for a request.
+package locale
+
+import (
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Default is the locale of recipes that do not name one.
+const Default = "en"
+
+// Supported lists the locales recipe content can be written in.
+var Supported = []string{"en", "es", "de"}
+
+func IsSupported(locale string) bool {
+	return slices.Contains(Supported, locale)
+}
+
+type preference struct {
+	tag    string
+	weight float64
+}
+
+// Negotiate returns the entry of available that best matches an Accept-Language
+// header. A regional tag such as es-MX also matches its language, es. It reports
+// false when the header accepts none of them.
+func Negotiate(acceptLanguage string, available []string) (string, bool) {
+	for _, preference := range parse(acceptLanguage) {
+		if preference.tag == "*" && len(available) > 0 {
+			return available[0], true
+		}
+		if slices.Contains(available, preference.tag) {
+			return preference.tag, true
+		}
+		language, _, found := strings.Cut(preference.tag, "-")
+		if found && slices.Contains(available, language) {
+			return language, true
+		}
+	}
+	return "", false
+}
+
+// parse splits an Accept-Language header into tags ordered by weight, dropping
+// those with a weight of zero.
+func parse(header string) []preference {
+	preferences := make([]preference, 0)
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" {
+			continue
+		}
+
+		weight := 1.0
+		if q, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(q, 64)
+			if err != nil {
+				continue
+			}
+			weight = parsed
+		}
+		if weight > 0 {
+			preferences = append(preferences, preference{tag: tag, weight: weight})
+		}
+	}
+
+	sort.SliceStable(preferences, func(i, j int) bool {
+		return preferences[i].weight > preferences[j].weight
+	})
+	return preferences
+}
@@ -2,14 +2,23 @@ package main
 
 import (
 	"context"
+	"os"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
 	"github.com/mahesh-yadav/go-recipes-api/config"
 	"github.com/mahesh-yadav/go-recipes-api/database"
 	_ "github.com/mahesh-yadav/go-recipes-api/docs"
 	"github.com/mahesh-yadav/go-recipes-api/handlers"
+	"github.com/mahesh-yadav/go-recipes-api/jobs"
 	"github.com/mahesh-yadav/go-recipes-api/logger"
 	"github.com/mahesh-yadav/go-recipes-api/middleware"
+	"github.com/mahesh-yadav/go-recipes-api/models"
+	"github.com/mahesh-yadav/go-recipes-api/search"
+	"github.com/mahesh-yadav/go-recipes-api/semantic"
+	"github.com/mahesh-yadav/go-recipes-api/storage"
 	"github.com/rs/zerolog/log"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
@@ -27,6 +36,11 @@ func main() {
 	log.Logger = logger.SetupLogger(config)
 
 	gin.SetMode(config.GinMode)
+	if validate, ok := binding.Validator.Engine().(*validator.Validate); ok {
+		if err := models.RegisterValidators(validate); err != nil {
+			log.Fatal().Err(err).Msg("Error registering validators")
+		}
+	}
 
 	database.ConnectToMongoDB(config)
 
@@ -38,15 +52,72 @@ func main() {
 	database.ConnectToRedis(config)
 	redisClient := database.GetRedisClient(config)
 
-	recipesHandler := handlers.NewRecipeHandler(ctx, recipeCollection, redisClient, config)
+	blobStore, err := storage.NewBlobStore(config)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Error creating blob store")
+	}
+
+	versionCollection := database.GetMongoCollection(config, "recipe_versions")
+	tagCollection := database.GetMongoCollection(config, "tags")
+	searchIndex, err := search.NewSearchIndex(config, recipeCollection)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Error creating search index")
+	}
+	embedder, err := semantic.NewEmbedder(config)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Error creating embedder")
+	}
+	recipesHandler := handlers.NewRecipeHandler(ctx, recipeCollection, versionCollection, tagCollection, redisClient, config, blobStore, searchIndex, embedder)
+	tagHandler := handlers.NewTagHandler(ctx, tagCollection, recipeCollection, recipesHandler)
+
+	// "recipes-api reindex" rebuilds the search index and exits.
+	if len(os.Args) > 1 && os.Args[1] == "reindex" {
+		count, err := recipesHandler.Reindex()
+		if err != nil {
+			log.Fatal().Err(err).Msg("Error rebuilding search index")
+		}
+		log.Info().Int("recipes", count).Msg("Search index rebuilt")
+		return
+	}
+	// "recipes-api import" and "recipes-api export" load or dump recipes in bulk and exit.
+	if len(os.Args) > 1 && os.Args[1] == "import" {
+		if err := importCommand(recipesHandler, os.Args[2:]); err != nil {
+			log.Fatal().Err(err).Msg("Error importing recipes")
+		}
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "export" {
+		if err := exportCommand(recipesHandler, os.Args[2:]); err != nil {
+			log.Fatal().Err(err).Msg("Error exporting recipes")
+		}
+		return
+	}
+	if err := recipesHandler.LoadSearchIndex(); err != nil {
+		log.Fatal().Err(err).Msg("Error loading search index")
+	}
+
+	publishInterval := time.Duration(config.PublishSchedulerIntervalSeconds) * time.Second
+	go jobs.RunScheduledPublisher(ctx, recipeCollection, publishInterval, recipesHandler.RecipesPublished)
+	trashRetention := time.Duration(config.TrashRetentionDays) * 24 * time.Hour
+	purgeInterval := time.Duration(config.TrashPurgeIntervalMinutes) * time.Minute
+	go jobs.RunTrashPurger(ctx, recipeCollection, trashRetention, purgeInterval, recipesHandler.PurgeRecipeData)
 
 	userCollection := database.GetMongoCollection(config, "users")
 	authHandler := handlers.NewAuthHandler(ctx, config, userCollection)
 
+	pantryCollection := database.GetMongoCollection(config, "pantries")
+	pantryHandler := handlers.NewPantryHandler(ctx, pantryCollection, recipeCollection)
+
+	cookbookCollection := database.GetMongoCollection(config, "cookbooks")
+	cookbookHandler := handlers.NewCookbookHandler(ctx, cookbookCollection, recipeCollection, blobStore)
+
 	router := gin.New()
 	router.Use(gin.Logger(), middleware.GlobalErrorMiddleware())
 
 	router.GET("/recipes", recipesHandler.ListRecipesHandler)
+	router.GET("/tags", tagHandler.ListTagsHandler)
+	router.GET("/suggest", recipesHandler.SuggestHandler)
+	router.GET("/shared/recipes/:id", recipesHandler.GetSharedRecipeHandler)
 	router.POST("/auth/signup", authHandler.SignUpHandler)
 	router.POST("/auth/signin", authHandler.SignInHandler)
 	router.POST("/auth/refresh", authHandler.AuthMiddlewareJWT(), authHandler.RefreshTokenHandler)
@@ -55,10 +126,53 @@ func main() {
 	authorized.Use(authHandler.AuthMiddlewareJWT())
 	{
 		authorized.POST("/recipes", recipesHandler.CreateRecipeHandler)
+		authorized.POST("/recipes/import", recipesHandler.ImportRecipeHandler)
+		authorized.GET("/recipes/mine", recipesHandler.ListMyRecipesHandler)
+		authorized.GET("/recipes/invitations", recipesHandler.ListInvitationsHandler)
+		authorized.GET("/recipes/trash", recipesHandler.ListTrashHandler)
+		authorized.POST("/recipes/:id/restore", recipesHandler.RestoreRecipeHandler)
 		authorized.GET("/recipes/:id", recipesHandler.GetRecipeHandler)
+		authorized.PUT("/recipes/:id/status", recipesHandler.UpdateRecipeStatusHandler)
+		authorized.POST("/recipes/:id/fork", recipesHandler.ForkRecipeHandler)
+		authorized.POST("/recipes/:id/share", recipesHandler.ShareRecipeHandler)
+		authorized.PUT("/recipes/:id/translations/:locale", recipesHandler.UpdateTranslationHandler)
+		authorized.DELETE("/recipes/:id/translations/:locale", recipesHandler.DeleteTranslationHandler)
+		authorized.POST("/recipes/:id/collaborators", recipesHandler.InviteCollaboratorHandler)
+		authorized.POST("/recipes/:id/collaborators/accept", recipesHandler.AcceptInvitationHandler)
+		authorized.DELETE("/recipes/:id/collaborators/:username", recipesHandler.RemoveCollaboratorHandler)
+		authorized.GET("/recipes/:id/lineage", recipesHandler.RecipeLineageHandler)
+		authorized.GET("/recipes/:id/similar", recipesHandler.SimilarRecipesHandler)
+		authorized.PUT("/recipes/:id/labels", recipesHandler.UpdateRecipeLabelsHandler)
+		authorized.DELETE("/recipes/:id/labels", recipesHandler.ResetRecipeLabelsHandler)
+		authorized.POST("/recipes/:id/images", recipesHandler.UploadRecipeImageHandler)
+		authorized.DELETE("/recipes/:id/images/:imageId", recipesHandler.DeleteRecipeImageHandler)
+		authorized.GET("/recipes/:id/versions", recipesHandler.ListRecipeVersionsHandler)
+		authorized.GET("/recipes/:id/versions/diff", recipesHandler.DiffRecipeVersionsHandler)
+		authorized.GET("/recipes/:id/versions/:v", recipesHandler.GetRecipeVersionHandler)
+		authorized.POST("/recipes/:id/versions/:v/restore", recipesHandler.RestoreRecipeVersionHandler)
 		authorized.PUT("/recipes/:id", recipesHandler.UpdateRecipeHandler)
 		authorized.DELETE("/recipes/:id", recipesHandler.DeleteRecipeHandler)
 		authorized.GET("/recipes/search", recipesHandler.SearchRecipeHandler)
+		authorized.GET("/recipes/semantic", recipesHandler.SemanticSearchHandler)
+		authorized.GET("/recipes/match", pantryHandler.MatchRecipesHandler)
+		authorized.POST("/tags", authHandler.AdminMiddleware(), tagHandler.CreateTagHandler)
+		authorized.PUT("/tags/:slug", authHandler.AdminMiddleware(), tagHandler.UpdateTagHandler)
+		authorized.DELETE("/tags/:slug", authHandler.AdminMiddleware(), tagHandler.DeleteTagHandler)
+		authorized.POST("/tags/:slug/merge", authHandler.AdminMiddleware(), tagHandler.MergeTagHandler)
+		authorized.POST("/admin/reindex", authHandler.AdminMiddleware(), recipesHandler.ReindexHandler)
+		authorized.POST("/admin/recipes/import", authHandler.AdminMiddleware(), recipesHandler.BulkImportHandler)
+		authorized.GET("/admin/recipes/export", authHandler.AdminMiddleware(), recipesHandler.BulkExportHandler)
+		authorized.GET("/pantry", pantryHandler.GetPantryHandler)
+		authorized.PUT("/pantry", pantryHandler.UpdatePantryHandler)
+		authorized.POST("/cookbooks", cookbookHandler.CreateCookbookHandler)
+		authorized.GET("/cookbooks", cookbookHandler.ListCookbooksHandler)
+		authorized.GET("/cookbooks/:id", cookbookHandler.GetCookbookHandler)
+		authorized.PUT("/cookbooks/:id", cookbookHandler.UpdateCookbookHandler)
+		authorized.DELETE("/cookbooks/:id", cookbookHandler.DeleteCookbookHandler)
+	}
+
+	if config.BlobStore == "local" {
+		router.Static(storage.LocalMediaPath, config.BlobLocalDir)
 	}
 
 	router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
@@ -0,0 +1,33 @@
+package models
+
+// BulkRecipe is one row of a bulk import. Rows with an external ID update the
+// recipe imported with the same ID, or exported with it, if any.
+type BulkRecipe struct {
+	AddUpdateRecipe
+	ExternalID string `json:"external_id" binding:"omitempty,max=200" example:"cookies-001"`
+	Status     string `json:"status" binding:"omitempty,oneof=draft in_review published archived" enums:"draft,in_review,published,archived" example:"published"`
+}
+
+type BulkImportParams struct {
+	Format string `form:"format" binding:"omitempty,oneof=jsonl csv"`
+	DryRun bool   `form:"dry_run"`
+}
+
+type BulkExportParams struct {
+	Format string `form:"format" binding:"omitempty,oneof=jsonl csv"`
+}
+
+type BulkImportReport struct {
+	DryRun  bool           `json:"dry_run" example:"false"`
+	Rows    int            `json:"rows" example:"120"`
+	Created int            `json:"created" example:"100"`
+	Updated int            `json:"updated" example:"18"`
+	Failed  int            `json:"failed" example:"2"`
+	Errors  []BulkRowError `json:"errors"`
+}
+
+type BulkRowError struct {
+	Line       int      `json:"line" example:"7"`
+	ExternalID string   `json:"external_id,omitempty" example:"cookies-001"`
+	Errors     []string `json:"errors" example:"name: required"`
+}
@@ -0,0 +1,68 @@
+package models
+
+import "time"
+
+const (
+	PermissionEditor = "editor"
+	PermissionViewer = "viewer"
+)
+
+// Collaborator is a user invited to work on a recipe. The invitation is pending until
+// the user accepts it.
+type Collaborator struct {
+	Username   string     `json:"username" bson:"username" example:"jane"`
+	Permission string     `json:"permission" bson:"permission" enums:"editor,viewer" example:"editor"`
+	InvitedBy  string     `json:"invited_by" bson:"invited_by" example:"admin"`
+	InvitedAt  time.Time  `json:"invited_at" bson:"invited_at" example:"2023-03-10T15:04:05Z"`
+	AcceptedAt *time.Time `json:"accepted_at,omitempty" bson:"accepted_at,omitempty" example:"2023-03-10T15:04:05Z"`
+}
+
+type InviteCollaborator struct {
+	Username   string `json:"username" binding:"required" example:"jane"`
+	Permission string `json:"permission" binding:"required,oneof=editor viewer" enums:"editor,viewer" example:"editor"`
+}
+
+// collaborator returns the accepted collaborator entry for username.
+func (recipe ViewRecipe) collaborator(username string) (Collaborator, bool) {
+	for _, collaborator := range recipe.Collaborators {
+		if collaborator.Username == username && collaborator.AcceptedAt != nil {
+			return collaborator, true
+		}
+	}
+	return Collaborator{}, false
+}
+
+// IsMember reports whether username is the recipe's author or an accepted collaborator.
+func (recipe ViewRecipe) IsMember(username string) bool {
+	if recipe.Author != "" && recipe.Author == username {
+		return true
+	}
+	_, ok := recipe.collaborator(username)
+	return ok
+}
+
+// HideCollaborators removes the collaborators username may not see. Members see them
+// all, invitees only their own invitation and everyone else none, so invitations stay
+// private until they are accepted.
+func (recipe *ViewRecipe) HideCollaborators(username string) {
+	if recipe.IsMember(username) {
+		return
+	}
+	var visible []Collaborator
+	for _, collaborator := range recipe.Collaborators {
+		if username != "" && collaborator.Username == username {
+			visible = append(visible, collaborator)
+		}
+	}
+	recipe.Collaborators = visible
+}
+
+// CanEdit reports whether username may change the recipe: its author or an editor.
+// Recipes without an author predate ownership and are left to admins.
+func (recipe ViewRecipe) CanEdit(username string) bool {
+	if recipe.Author != "" && recipe.Author == username {
+		return true
+	}
+	collaborator, ok := recipe.collaborator(username)
+	return ok && collaborator.Permission == PermissionEditor
+}
@@ -0,0 +1,28 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+type Cookbook struct {
+	ID          bson.ObjectID   `json:"id" bson:"_id" example:"c0283p3d0cvuglq85log"`
+	Name        string          `json:"name" bson:"name" example:"Sunday Baking"`
+	Description string          `json:"description,omitempty" bson:"description,omitempty" example:"Cakes and cookies for the weekend"`
+	Owner       string          `json:"owner" bson:"owner" example:"admin"`
+	RecipeIDs   []bson.ObjectID `json:"recipe_ids" bson:"recipe_ids" example:"c0283p3d0cvuglq85log"`
+	CreatedAt   time.Time       `json:"created_at" bson:"created_at" example:"2023-03-10T15:04:05Z"`
+	UpdatedAt   time.Time       `json:"updated_at" bson:"updated_at" example:"2023-03-10T15:04:05Z"`
+}
+
+type AddUpdateCookbook struct {
+	Name        string   `json:"name" binding:"required,max=200" example:"Sunday Baking"`
+	Description string   `json:"description" binding:"max=2000" example:"Cakes and cookies for the weekend"`
+	RecipeIDs   []string `json:"recipe_ids" binding:"required,max=200" example:"c0283p3d0cvuglq85log"`
+}
+
+type ListCookbooks struct {
+	Count int        `json:"count"`
+	Data  []Cookbook `json:"data"`
+}
@@ -3,4 +3,7 @@ package models
 type ErrorResponse struct {
 	Code    int    `json:"code"`
 	Message string `json:"message"`
+	// Token and Column point at the offending part of a search query.
+	Token  string `json:"token,omitempty" example:"time<abc"`
+	Column int    `json:"column,omitempty" example:"12"`
 }
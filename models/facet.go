@@ -0,0 +1,27 @@
+package models
+
+type FacetValue struct {
+	Value    string `json:"value" bson:"_id" example:"italian"`
+	Count    int    `json:"count" bson:"count" example:"12"`
+	Selected bool   `json:"selected" bson:"-" example:"false"`
+}
+
+// RecipeFacets counts the matching recipes per facet value. Each facet ignores its
+// own selection, so the counts show what selecting another value would add.
+type RecipeFacets struct {
+	Tags       []FacetValue `json:"tags"`
+	Cuisine    []FacetValue `json:"cuisine"`
+	Difficulty []FacetValue `json:"difficulty"`
+	TotalTime  []FacetValue `json:"total_time"`
+}
+
+type RecipeSearchResult struct {
+	Count    int          `json:"count" example:"1"`
+	Total    int          `json:"total" example:"42"`
+	Page     int          `json:"page" example:"1"`
+	PageSize int          `json:"page_size" example:"20"`
+	Data     []ViewRecipe `json:"data"`
+	Facets   RecipeFacets `json:"facets"`
+	// DidYouMean is a spelling correction of the text query, offered when few recipes match.
+	DidYouMean string `json:"did_you_mean,omitempty" example:"chicken"`
+}
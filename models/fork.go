@@ -0,0 +1,30 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// LineageRecipe is a recipe in a fork tree. Depth counts the forks between it and
+// the recipe the lineage was requested for, starting at 1.
+type LineageRecipe struct {
+	ID            bson.ObjectID  `json:"id" bson:"_id" example:"c0283p3d0cvuglq85log"`
+	Name          string         `json:"name" bson:"name" example:"Chocolate Chip Cookies"`
+	Author        string         `json:"author,omitempty" bson:"author,omitempty" example:"admin"`
+	ForkedFrom    *bson.ObjectID `json:"forked_from,omitempty" bson:"forked_from,omitempty" example:"c0283p3d0cvuglq85log"`
+	ForkCount     int            `json:"fork_count" bson:"fork_count" example:"2"`
+	Depth         int            `json:"depth" bson:"depth" example:"1"`
+	Status        string         `json:"-" bson:"status,omitempty"`
+	Visibility    string         `json:"-" bson:"visibility,omitempty"`
+	DeletedAt     *time.Time     `json:"-" bson:"deleted_at,omitempty"`
+	Collaborators []Collaborator `json:"-" bson:"collaborators,omitempty"`
+}
+
+// RecipeLineage lists the recipes a recipe was forked from, nearest first, and the
+// forks made from it, ordered by depth.
+type RecipeLineage struct {
+	Recipe      LineageRecipe   `json:"recipe"`
+	Ancestors   []LineageRecipe `json:"ancestors"`
+	Descendants []LineageRecipe `json:"descendants"`
+}
@@ -0,0 +1,31 @@
+package models
+
+import "time"
+
+type Pantry struct {
+	Username  string    `json:"username" bson:"username" example:"admin"`
+	Items     []string  `json:"items" bson:"items" example:"egg,flour,milk"`
+	UpdatedAt time.Time `json:"updated_at" bson:"updated_at" example:"2023-03-10T15:04:05Z"`
+}
+
+type UpdatePantry struct {
+	Items []string `json:"items" binding:"required" example:"egg,flour,milk"`
+}
+
+type PantryMatchParams struct {
+	Have  string `form:"have"`
+	Limit int    `form:"limit" binding:"omitempty,min=1,max=100"`
+}
+
+type RecipeMatch struct {
+	Recipe   ViewRecipe `json:"recipe"`
+	Matched  int        `json:"matched" example:"5"`
+	Total    int        `json:"total" example:"7"`
+	Coverage float64    `json:"coverage" example:"0.71"`
+	Missing  []string   `json:"missing" example:"brown sugar,semi-sweet chocolate chips"`
+}
+
+type ListRecipeMatches struct {
+	Count int           `json:"count"`
+	Data  []RecipeMatch `json:"data"`
+}
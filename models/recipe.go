@@ -7,27 +7,104 @@ import (
 )
 
 type Recipe struct {
-	Name         string    `json:"name" bson:"name" binding:"required" example:"Chocolate Chip Cookies"`
-	Tags         []string  `json:"tags" bson:"tags" binding:"required" example:"dessert,snack"`
-	Ingredients  []string  `json:"ingredients" bson:"ingredients" binding:"required" example:"2 1/4 cups all-purpose flour,1 tsp baking soda,1 cup butter,3/4 cup granulated sugar,3/4 cup brown sugar,2 large eggs,2 cups semi-sweet chocolate chips"`
-	Instructions []string  `json:"instructions" bson:"instructions" binding:"required" example:"Preheat oven to 375°F (190°C),Mix dry ingredients,Cream butter and sugars,Beat in eggs,Stir in chocolate chips,Drop spoonfuls onto baking sheets,Bake for 9 to 11 minutes"`
-	PublishedAt  time.Time `json:"published_at" bson:"published_at" example:"2023-03-10T15:04:05Z"`
+	Name             string                       `json:"name" bson:"name" binding:"required" example:"Chocolate Chip Cookies"`
+	Tags             []string                     `json:"tags" bson:"tags" binding:"required" example:"dessert,snack"`
+	Ingredients      []string                     `json:"ingredients" bson:"ingredients" binding:"required" example:"2 1/4 cups all-purpose flour,1 tsp baking soda,1 cup butter,3/4 cup granulated sugar,3/4 cup brown sugar,2 large eggs,2 cups semi-sweet chocolate chips"`
+	Instructions     Instructions                 `json:"instructions" bson:"instructions" binding:"required,dive"`
+	Servings         int                          `json:"servings,omitempty" bson:"servings,omitempty" example:"4"`
+	PrepTime         string                       `json:"prep_time,omitempty" bson:"prep_time,omitempty" example:"PT15M"`
+	CookTime         string                       `json:"cook_time,omitempty" bson:"cook_time,omitempty" example:"PT10M"`
+	TotalTime        string                       `json:"total_time,omitempty" bson:"total_time,omitempty" example:"PT25M"`
+	TotalTimeMinutes int                          `json:"-" bson:"total_time_minutes,omitempty"`
+	Difficulty       string                       `json:"difficulty,omitempty" bson:"difficulty,omitempty" enums:"easy,medium,hard" example:"easy"`
+	Cuisine          string                       `json:"cuisine,omitempty" bson:"cuisine,omitempty" example:"american"`
+	Course           string                       `json:"course,omitempty" bson:"course,omitempty" example:"dessert"`
+	Equipment        []string                     `json:"equipment,omitempty" bson:"equipment,omitempty" example:"mixing bowl,baking sheet"`
+	Yield            string                       `json:"yield,omitempty" bson:"yield,omitempty" example:"24 cookies"`
+	Attribution      *Attribution                 `json:"attribution,omitempty" bson:"attribution,omitempty"`
+	Visibility       string                       `json:"visibility,omitempty" bson:"visibility,omitempty" enums:"public,unlisted,private" example:"public"`
+	DefaultLocale    string                       `json:"default_locale,omitempty" bson:"default_locale,omitempty" enums:"en,es,de" example:"en"`
+	Translations     map[string]RecipeTranslation `json:"translations,omitempty" bson:"translations,omitempty"`
+	Nutrition        *Nutrition                   `json:"nutrition,omitempty" bson:"nutrition,omitempty"`
+	Author           string                       `json:"author,omitempty" bson:"author,omitempty" example:"admin"`
+	Allergens        []string                     `json:"allergens" bson:"allergens" example:"gluten,dairy,eggs"`
+	Diets            []string                     `json:"diets" bson:"diets" example:"vegetarian,nut-free"`
+	LabelsOverridden bool                         `json:"labels_overridden" bson:"labels_overridden"`
+	Version          int                          `json:"version" bson:"version" example:"1"`
+	Status           string                       `json:"status" bson:"status" enums:"draft,in_review,published,archived" example:"published"`
+	CreatedAt        time.Time                    `json:"created_at" bson:"created_at" example:"2023-03-10T15:04:05Z"`
+	UpdatedAt        time.Time                    `json:"updated_at" bson:"updated_at" example:"2023-03-10T15:04:05Z"`
+	ScheduledAt      *time.Time                   `json:"scheduled_at,omitempty" bson:"scheduled_at,omitempty" example:"2023-03-10T15:04:05Z"`
+	PublishedAt      *time.Time                   `json:"published_at,omitempty" bson:"published_at,omitempty" example:"2023-03-10T15:04:05Z"`
+	ForkedFrom       *bson.ObjectID               `json:"forked_from,omitempty" bson:"forked_from,omitempty" example:"c0283p3d0cvuglq85log"`
+	ExternalID       string                       `json:"external_id,omitempty" bson:"external_id,omitempty" example:"cookies-001"`
 }
 
 type ViewRecipe struct {
-	ID           bson.ObjectID `json:"id" bson:"_id" example:"c0283p3d0cvuglq85log"`
-	Name         string        `json:"name" bson:"name" example:"Chocolate Chip Cookies"`
-	Tags         []string      `json:"tags" bson:"tags" example:"dessert,snack"`
-	Ingredients  []string      `json:"ingredients" bson:"ingredients" example:"2 1/4 cups all-purpose flour,1 tsp baking soda,1 cup butter,3/4 cup granulated sugar,3/4 cup brown sugar,2 large eggs,2 cups semi-sweet chocolate chips"`
-	Instructions []string      `json:"instructions" bson:"instructions" example:"Preheat oven to 375°F (190°C),Mix dry ingredients,Cream butter and sugars,Beat in eggs,Stir in chocolate chips,Drop spoonfuls onto baking sheets,Bake for 9 to 11 minutes"`
-	PublishedAt  time.Time     `json:"published_at" bson:"published_at" example:"2023-03-10T15:04:05Z"`
+	ID               bson.ObjectID                `json:"id" bson:"_id" example:"c0283p3d0cvuglq85log"`
+	Name             string                       `json:"name" bson:"name" example:"Chocolate Chip Cookies"`
+	Tags             []string                     `json:"tags" bson:"tags" example:"dessert,snack"`
+	Ingredients      []string                     `json:"ingredients" bson:"ingredients" example:"2 1/4 cups all-purpose flour,1 tsp baking soda,1 cup butter,3/4 cup granulated sugar,3/4 cup brown sugar,2 large eggs,2 cups semi-sweet chocolate chips"`
+	Instructions     Instructions                 `json:"instructions" bson:"instructions"`
+	Servings         int                          `json:"servings,omitempty" bson:"servings,omitempty" example:"4"`
+	PrepTime         string                       `json:"prep_time,omitempty" bson:"prep_time,omitempty" example:"PT15M"`
+	CookTime         string                       `json:"cook_time,omitempty" bson:"cook_time,omitempty" example:"PT10M"`
+	TotalTime        string                       `json:"total_time,omitempty" bson:"total_time,omitempty" example:"PT25M"`
+	TotalTimeMinutes int                          `json:"-" bson:"total_time_minutes,omitempty"`
+	Difficulty       string                       `json:"difficulty,omitempty" bson:"difficulty,omitempty" enums:"easy,medium,hard" example:"easy"`
+	Cuisine          string                       `json:"cuisine,omitempty" bson:"cuisine,omitempty" example:"american"`
+	Course           string                       `json:"course,omitempty" bson:"course,omitempty" example:"dessert"`
+	Equipment        []string                     `json:"equipment,omitempty" bson:"equipment,omitempty" example:"mixing bowl,baking sheet"`
+	Yield            string                       `json:"yield,omitempty" bson:"yield,omitempty" example:"24 cookies"`
+	Attribution      *Attribution                 `json:"attribution,omitempty" bson:"attribution,omitempty"`
+	Visibility       string                       `json:"visibility,omitempty" bson:"visibility,omitempty" enums:"public,unlisted,private" example:"public"`
+	DefaultLocale    string                       `json:"default_locale,omitempty" bson:"default_locale,omitempty" enums:"en,es,de" example:"en"`
+	Translations     map[string]RecipeTranslation `json:"translations,omitempty" bson:"translations,omitempty"`
+	Images           []RecipeImage                `json:"images,omitempty" bson:"images,omitempty"`
+	Nutrition        *Nutrition                   `json:"nutrition,omitempty" bson:"nutrition,omitempty"`
+	Author           string                       `json:"author,omitempty" bson:"author,omitempty" example:"admin"`
+	Allergens        []string                     `json:"allergens" bson:"allergens" example:"gluten,dairy,eggs"`
+	Diets            []string                     `json:"diets" bson:"diets" example:"vegetarian,nut-free"`
+	LabelsOverridden bool                         `json:"labels_overridden" bson:"labels_overridden"`
+	Version          int                          `json:"version" bson:"version" example:"3"`
+	Status           string                       `json:"status,omitempty" bson:"status,omitempty" enums:"draft,in_review,published,archived" example:"published"`
+	CreatedAt        *time.Time                   `json:"created_at,omitempty" bson:"created_at,omitempty" example:"2023-03-10T15:04:05Z"`
+	UpdatedAt        *time.Time                   `json:"updated_at,omitempty" bson:"updated_at,omitempty" example:"2023-03-10T15:04:05Z"`
+	ScheduledAt      *time.Time                   `json:"scheduled_at,omitempty" bson:"scheduled_at,omitempty" example:"2023-03-10T15:04:05Z"`
+	PublishedAt      *time.Time                   `json:"published_at,omitempty" bson:"published_at,omitempty" example:"2023-03-10T15:04:05Z"`
+	DeletedAt        *time.Time                   `json:"deleted_at,omitempty" bson:"deleted_at,omitempty" example:"2023-03-10T15:04:05Z"`
+	DeletedBy        string                       `json:"deleted_by,omitempty" bson:"deleted_by,omitempty" example:"admin"`
+	ForkedFrom       *bson.ObjectID               `json:"forked_from,omitempty" bson:"forked_from,omitempty" example:"c0283p3d0cvuglq85log"`
+	ForkCount        int                          `json:"fork_count" bson:"fork_count" example:"2"`
+	Collaborators    []Collaborator               `json:"collaborators,omitempty" bson:"collaborators,omitempty"`
+	UpdatedBy        string                       `json:"updated_by,omitempty" bson:"updated_by,omitempty" example:"jane"`
+	ExternalID       string                       `json:"external_id,omitempty" bson:"external_id,omitempty" example:"cookies-001"`
+	Locale           string                       `json:"locale,omitempty" bson:"-" example:"en"`
 }
 
 type AddUpdateRecipe struct {
-	Name         string   `json:"name" binding:"required" example:"Chocolate Chip Cookies"`
-	Tags         []string `json:"tags" binding:"required" example:"dessert,snack"`
-	Ingredients  []string `json:"ingredients" binding:"required" example:"2 1/4 cups all-purpose flour,1 tsp baking soda,1 cup butter,3/4 cup granulated sugar,3/4 cup brown sugar,2 large eggs,2 cups semi-sweet chocolate chips"`
-	Instructions []string `json:"instructions" binding:"required" example:"Preheat oven to 375°F (190°C),Mix dry ingredients,Cream butter and sugars,Beat in eggs,Stir in chocolate chips,Drop spoonfuls onto baking sheets,Bake for 9 to 11 minutes"`
+	Name          string       `json:"name" binding:"required" example:"Chocolate Chip Cookies"`
+	Tags          []string     `json:"tags" binding:"required" example:"dessert,snack"`
+	Ingredients   []string     `json:"ingredients" binding:"required" example:"2 1/4 cups all-purpose flour,1 tsp baking soda,1 cup butter,3/4 cup granulated sugar,3/4 cup brown sugar,2 large eggs,2 cups semi-sweet chocolate chips"`
+	Instructions  Instructions `json:"instructions" binding:"required,dive"`
+	Servings      int          `json:"servings" binding:"omitempty,min=1" example:"4"`
+	PrepTime      string       `json:"prep_time" binding:"omitempty,iso8601duration" example:"PT15M"`
+	CookTime      string       `json:"cook_time" binding:"omitempty,iso8601duration" example:"PT10M"`
+	TotalTime     string       `json:"total_time" binding:"omitempty,iso8601duration" example:"PT25M"`
+	Difficulty    string       `json:"difficulty" binding:"omitempty,oneof=easy medium hard" enums:"easy,medium,hard" example:"easy"`
+	Cuisine       string       `json:"cuisine" binding:"omitempty,max=50" example:"american"`
+	Course        string       `json:"course" binding:"omitempty,oneof=breakfast brunch lunch dinner appetizer main side dessert snack drink" enums:"breakfast,brunch,lunch,dinner,appetizer,main,side,dessert,snack,drink" example:"dessert"`
+	Equipment     []string     `json:"equipment" binding:"omitempty,dive,min=1,max=100" example:"mixing bowl,baking sheet"`
+	Yield         string       `json:"yield" binding:"omitempty,max=100" example:"24 cookies"`
+	Attribution   *Attribution `json:"attribution" binding:"omitempty"`
+	Visibility    string       `json:"visibility" binding:"omitempty,oneof=public unlisted private" enums:"public,unlisted,private" example:"public"`
+	DefaultLocale string       `json:"default_locale" binding:"omitempty,oneof=en es de" enums:"en,es,de" example:"en"`
+}
+
+type Attribution struct {
+	Source string `json:"source,omitempty" bson:"source,omitempty" binding:"omitempty,max=200" example:"Grandma's recipe box"`
+	Author string `json:"author,omitempty" bson:"author,omitempty" binding:"omitempty,max=200" example:"Ruth Wakefield"`
+	URL    string `json:"url,omitempty" bson:"url,omitempty" binding:"omitempty,url" example:"https://example.com/chocolate-chip-cookies"`
 }
 
 type ListRecipes struct {
@@ -35,6 +112,67 @@ type ListRecipes struct {
 	Data  []ViewRecipe `json:"data"`
 }
 
+type RecipeFilterParams struct {
+	ExcludeAllergens string `form:"exclude_allergens"`
+	Diet             string `form:"diet"`
+	MaxTotalTime     string `form:"max_total_time"`
+	Cuisine          string `form:"cuisine"`
+	Course           string `form:"course"`
+	Difficulty       string `form:"difficulty"`
+}
+
 type RecipeTagSearchParams struct {
-	Tag string `form:"tag" binding:"required,min=3"`
+	Query      string `form:"q" binding:"omitempty,max=200"`
+	Expression string `form:"query"`
+	Fuzzy      bool   `form:"fuzzy"`
+	Tag        string `form:"tag"`
+	Have       string `form:"have"`
+	TotalTime  string `form:"total_time"`
+	Page       int    `form:"page" binding:"omitempty,min=1"`
+	PageSize   int    `form:"page_size" binding:"omitempty,min=1,max=100"`
+	RecipeFilterParams
+}
+
+type UpdateRecipeLabels struct {
+	Allergens []string `json:"allergens" binding:"required" example:"gluten,dairy"`
+	Diets     []string `json:"diets" binding:"required" example:"vegetarian"`
+}
+
+type SimilarRecipeParams struct {
+	Limit int `form:"limit" binding:"omitempty,min=1,max=50"`
+}
+
+type SimilarRecipe struct {
+	Recipe ViewRecipe `json:"recipe"`
+	Score  float64    `json:"score" example:"0.42"`
+}
+
+type ListSimilarRecipes struct {
+	Count int             `json:"count"`
+	Data  []SimilarRecipe `json:"data"`
+}
+
+type Nutrition struct {
+	Servings   int      `json:"servings" bson:"servings" example:"4"`
+	Calories   float64  `json:"calories" bson:"calories" example:"412.5"`
+	ProteinG   float64  `json:"protein_g" bson:"protein_g" example:"6.1"`
+	FatG       float64  `json:"fat_g" bson:"fat_g" example:"21.3"`
+	CarbsG     float64  `json:"carbs_g" bson:"carbs_g" example:"52.8"`
+	SodiumMg   float64  `json:"sodium_mg" bson:"sodium_mg" example:"310.2"`
+	Confidence string   `json:"confidence" bson:"confidence" enums:"high,medium,low" example:"high"`
+	Unmatched  []string `json:"unmatched" bson:"unmatched" example:"semi-sweet chocolate chips"`
+}
+
+type RecipeImage struct {
+	ID          string            `json:"id" bson:"id" example:"65f1c0e2a1b2c3d4e5f60718"`
+	ContentType string            `json:"content_type" bson:"content_type" example:"image/jpeg"`
+	Width       int               `json:"width" bson:"width" example:"2048"`
+	Height      int               `json:"height" bson:"height" example:"1536"`
+	URLs        map[string]string `json:"urls" bson:"urls"`
+	Keys        []string          `json:"-" bson:"keys"`
+	UploadedAt  time.Time         `json:"uploaded_at" bson:"uploaded_at" example:"2023-03-10T15:04:05Z"`
+}
+
+type ImportRecipeParams struct {
+	Preview bool `form:"preview"`
 }
@@ -0,0 +1,14 @@
+package models
+
+// RecipeEmbedding is stored on each listed recipe. Hash identifies the embedded text
+// and model, so the vector is only recomputed when either changes.
+type RecipeEmbedding struct {
+	Model  string    `json:"model" bson:"model"`
+	Hash   string    `json:"hash" bson:"hash"`
+	Vector []float32 `json:"vector" bson:"vector"`
+}
+
+type SemanticSearchParams struct {
+	Query string `form:"q" binding:"required"`
+	Limit int    `form:"limit" binding:"omitempty,min=1,max=50"`
+}
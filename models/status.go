@@ -0,0 +1,25 @@
+package models
+
+import "time"
+
+const (
+	StatusDraft     = "draft"
+	StatusInReview  = "in_review"
+	StatusPublished = "published"
+	StatusArchived  = "archived"
+)
+
+type UpdateRecipeStatus struct {
+	Status    string     `json:"status" binding:"required,oneof=draft in_review published archived" enums:"draft,in_review,published,archived" example:"published"`
+	PublishAt *time.Time `json:"publish_at" example:"2023-03-10T15:04:05Z"`
+}
+
+type RecipeStatusParams struct {
+	Status string `form:"status" binding:"omitempty,oneof=draft in_review published archived"`
+}
+
+// IsPublished reports whether the recipe is public. Recipes created before the
+// publishing workflow have no status and were always public.
+func (recipe ViewRecipe) IsPublished() bool {
+	return recipe.Status == "" || recipe.Status == StatusPublished
+}
@@ -0,0 +1,77 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+type Step struct {
+	Section      string `json:"section,omitempty" bson:"section,omitempty" example:"To cook the chicken"`
+	Text         string `json:"text" bson:"text" binding:"required" example:"Bake for 9 to 11 minutes"`
+	TimerSeconds int    `json:"timer_seconds,omitempty" bson:"timer_seconds,omitempty" binding:"omitempty,min=0" example:"660"`
+	ImageID      string `json:"image_id,omitempty" bson:"image_id,omitempty" example:"65f1c0e2a1b2c3d4e5f60718"`
+	Ingredients  []int  `json:"ingredients,omitempty" bson:"ingredients,omitempty" binding:"omitempty,dive,min=0" example:"0,1"`
+}
+
+// Instructions accepts both structured steps and, for backward compatibility, plain
+// strings, in JSON requests as well as in documents stored before steps existed.
+type Instructions []Step
+
+func (instructions *Instructions) UnmarshalJSON(data []byte) error {
+	values := make([]json.RawMessage, 0)
+	if err := json.Unmarshal(data, &values); err != nil {
+		return err
+	}
+
+	steps := make(Instructions, 0, len(values))
+	for _, value := range values {
+		var text string
+		if err := json.Unmarshal(value, &text); err == nil {
+			steps = append(steps, Step{Text: text})
+			continue
+		}
+
+		var step Step
+		if err := json.Unmarshal(value, &step); err != nil {
+			return fmt.Errorf("instruction must be a string or a step object: %w", err)
+		}
+		steps = append(steps, step)
+	}
+	*instructions = steps
+	return nil
+}
+
+func (instructions *Instructions) UnmarshalBSONValue(typ byte, data []byte) error {
+	raw := bson.RawValue{Type: bson.Type(typ), Value: data}
+	if raw.Type == bson.TypeNull {
+		*instructions = nil
+		return nil
+	}
+
+	array, ok := raw.ArrayOK()
+	if !ok {
+		return fmt.Errorf("cannot decode %s into instructions", raw.Type)
+	}
+	values, err := array.Values()
+	if err != nil {
+		return err
+	}
+
+	steps := make(Instructions, 0, len(values))
+	for _, value := range values {
+		if text, ok := value.StringValueOK(); ok {
+			steps = append(steps, Step{Text: text})
+			continue
+		}
+
+		var step Step
+		if err := value.Unmarshal(&step); err != nil {
+			return err
+		}
+		steps = append(steps, step)
+	}
+	*instructions = steps
+	return nil
+}
@@ -0,0 +1,21 @@
+package models
+
+type SuggestParams struct {
+	Query string `form:"q" binding:"required"`
+	Limit int    `form:"limit" binding:"omitempty,min=1,max=20"`
+}
+
+type Suggestion struct {
+	Text  string `json:"text" example:"chocolate chip"`
+	Type  string `json:"type" enums:"name,tag,ingredient" example:"ingredient"`
+	Count int    `json:"count" example:"4"`
+}
+
+type ListSuggestions struct {
+	Count int          `json:"count" example:"1"`
+	Data  []Suggestion `json:"data"`
+}
+
+type ReindexResult struct {
+	Count int `json:"count" example:"42"`
+}
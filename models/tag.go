@@ -0,0 +1,34 @@
+package models
+
+// Tag is a managed tag. Recipes store the slug; synonyms resolve to it and searching
+// a tag also finds recipes tagged with its children.
+type Tag struct {
+	Slug     string   `json:"slug" bson:"_id" example:"vegetarian"`
+	Name     string   `json:"name" bson:"name" example:"Vegetarian"`
+	Synonyms []string `json:"synonyms" bson:"synonyms" example:"veggie,meatless"`
+	Parent   string   `json:"parent,omitempty" bson:"parent,omitempty" example:"diet"`
+}
+
+type AddUpdateTag struct {
+	Slug     string   `json:"slug" example:"vegetarian"`
+	Name     string   `json:"name" binding:"required" example:"Vegetarian"`
+	Synonyms []string `json:"synonyms" example:"veggie,meatless"`
+	Parent   string   `json:"parent" example:"diet"`
+}
+
+type MergeTag struct {
+	Into string `json:"into" binding:"required" example:"vegetarian"`
+}
+
+// TagUsage is a tag with the number of published recipes using it. Managed is false
+// for tags used by recipes that are not in the taxonomy yet.
+type TagUsage struct {
+	Tag
+	Count   int  `json:"count" example:"12"`
+	Managed bool `json:"managed" example:"true"`
+}
+
+type ListTags struct {
+	Count int        `json:"count" example:"1"`
+	Data  []TagUsage `json:"data"`
+}
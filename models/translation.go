@@ -0,0 +1,48 @@
+package models
+
+import "github.com/mahesh-yadav/go-recipes-api/locale"
+
+// RecipeTranslation holds a recipe's text in a locale other than its default one.
+type RecipeTranslation struct {
+	Name         string       `json:"name" bson:"name" example:"Galletas con chispas de chocolate"`
+	Ingredients  []string     `json:"ingredients" bson:"ingredients" example:"2 1/4 tazas de harina,1 cucharadita de bicarbonato"`
+	Instructions Instructions `json:"instructions" bson:"instructions"`
+}
+
+type AddUpdateTranslation struct {
+	Name         string       `json:"name" binding:"required" example:"Galletas con chispas de chocolate"`
+	Ingredients  []string     `json:"ingredients" binding:"required" example:"2 1/4 tazas de harina,1 cucharadita de bicarbonato"`
+	Instructions Instructions `json:"instructions" binding:"required,dive"`
+}
+
+// Locales lists the locales the recipe is available in, its default locale first.
+func (recipe ViewRecipe) Locales() []string {
+	locales := []string{recipe.defaultLocale()}
+	for code := range recipe.Translations {
+		if code != locales[0] {
+			locales = append(locales, code)
+		}
+	}
+	return locales
+}
+
+// Localize replaces the recipe's text with its translation for code, falling back
+// to the default locale when there is none, and records the locale used.
+func (recipe *ViewRecipe) Localize(code string) {
+	recipe.Locale = recipe.defaultLocale()
+	translation, ok := recipe.Translations[code]
+	if !ok || code == recipe.Locale {
+		return
+	}
+	recipe.Name = translation.Name
+	recipe.Ingredients = translation.Ingredients
+	recipe.Instructions = translation.Instructions
+	recipe.Locale = code
+}
+
+func (recipe ViewRecipe) defaultLocale() string {
+	if recipe.DefaultLocale == "" {
+		return locale.Default
+	}
+	return recipe.DefaultLocale
+}
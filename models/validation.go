@@ -0,0 +1,13 @@
+package models
+
+import (
+	"github.com/go-playground/validator/v10"
+	"github.com/mahesh-yadav/go-recipes-api/isoduration"
+)
+
+func RegisterValidators(validate *validator.Validate) error {
+	return validate.RegisterValidation("iso8601duration", func(fl validator.FieldLevel) bool {
+		_, err := isoduration.Parse(fl.Field().String())
+		return err == nil
+	})
+}
@@ -0,0 +1,41 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// RecipeVersion is a snapshot of a recipe as it was before an edit. Editor and EditedAt
+// record the edit that replaced it.
+type RecipeVersion struct {
+	ID       bson.ObjectID `json:"-" bson:"_id,omitempty"`
+	RecipeID bson.ObjectID `json:"recipe_id" bson:"recipe_id" example:"c0283p3d0cvuglq85log"`
+	Version  int           `json:"version" bson:"version" example:"2"`
+	Editor   string        `json:"editor" bson:"editor" example:"admin"`
+	EditedAt time.Time     `json:"edited_at" bson:"edited_at" example:"2023-03-10T15:04:05Z"`
+	Recipe   ViewRecipe    `json:"recipe" bson:"recipe"`
+}
+
+type ListRecipeVersions struct {
+	Count int             `json:"count"`
+	Data  []RecipeVersion `json:"data"`
+}
+
+type RecipeDiffParams struct {
+	From int `form:"from" binding:"required,min=1"`
+	To   int `form:"to" binding:"omitempty,min=1"`
+}
+
+type FieldChange struct {
+	Field string `json:"field" example:"ingredients"`
+	From  any    `json:"from"`
+	To    any    `json:"to"`
+}
+
+type RecipeDiff struct {
+	RecipeID bson.ObjectID `json:"recipe_id" example:"c0283p3d0cvuglq85log"`
+	From     int           `json:"from" example:"1"`
+	To       int           `json:"to" example:"3"`
+	Changes  []FieldChange `json:"changes"`
+}
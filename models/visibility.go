@@ -0,0 +1,37 @@
+package models
+
+import "time"
+
+const (
+	VisibilityPublic   = "public"
+	VisibilityUnlisted = "unlisted"
+	VisibilityPrivate  = "private"
+)
+
+type ShareRecipe struct {
+	ExpiresInHours int `json:"expires_in_hours" binding:"omitempty,min=1,max=720" example:"24"`
+}
+
+type ShareLink struct {
+	URL       string    `json:"url" example:"http://localhost:8080/shared/recipes/c0283p3d0cvuglq85log?expires=1678460645&signature=5f2b..."`
+	ExpiresAt time.Time `json:"expires_at" example:"2023-03-10T15:04:05Z"`
+}
+
+type ShareLinkParams struct {
+	Expires   int64  `form:"expires" binding:"required"`
+	Signature string `form:"signature" binding:"required"`
+}
+
+// IsListed reports whether the recipe shows up in lists and search results.
+func (recipe ViewRecipe) IsListed() bool {
+	return recipe.IsPublished() && (recipe.Visibility == "" || recipe.Visibility == VisibilityPublic)
+}
+
+// CanView reports whether username may open the recipe by its ID. Authors and
+// collaborators always can, everyone else only once it is published and not private.
+func (recipe ViewRecipe) CanView(username string) bool {
+	if recipe.IsMember(username) {
+		return true
+	}
+	return recipe.IsPublished() && recipe.Visibility != VisibilityPrivate
+}
@@ -0,0 +1,219 @@
+package nutrition
+
+import (
+	_ "embed"
+	"encoding/json"
+	"math"
+	"regexp"
+
+	"github.com/mahesh-yadav/go-recipes-api/ingredients"
+	"github.com/mahesh-yadav/go-recipes-api/models"
+)
+
+//go:embed nutrients.json
+var nutrientsJSON []byte
+
+// Nutrient holds values per 100 g of an ingredient, USDA style, along with the weights
+// needed to convert volume and counted quantities to grams.
+type Nutrient struct {
+	Name         string             `json:"name"`
+	Aliases      []string           `json:"aliases"`
+	Calories     float64            `json:"calories"`
+	ProteinG     float64            `json:"protein_g"`
+	FatG         float64            `json:"fat_g"`
+	CarbsG       float64            `json:"carbs_g"`
+	SodiumMg     float64            `json:"sodium_mg"`
+	GramsPerCup  float64            `json:"grams_per_cup"`
+	GramsPerUnit map[string]float64 `json:"grams_per_unit"`
+}
+
+type entry struct {
+	nutrient *Nutrient
+	tokens   []string
+}
+
+var (
+	gramsPerWeightUnit = map[string]float64{"g": 1, "kg": 1000, "oz": 28.35, "lb": 453.6}
+	cupsPerVolumeUnit  = map[string]float64{
+		"cup": 1, "tbsp": 1.0 / 16, "tsp": 1.0 / 48,
+		"ml": 1 / 236.6, "l": 1000 / 236.6, "quart": 4, "pint": 2,
+		"pinch": 1.0 / 768, "dash": 1.0 / 384,
+	}
+)
+
+// Seasonings are added by taste rather than measured, so lines naming them without a
+// quantity are too small to matter. Other lines without a quantity cannot be estimated.
+var (
+	seasonings          = map[string]bool{"salt": true, "pepper": true}
+	seasoningQualifiers = map[string]bool{
+		"kosher": true, "sea": true, "flaky": true, "black": true, "white": true, "ground": true, "cracked": true,
+	}
+	toTasteRegex = regexp.MustCompile(`(?i)\b(to taste|as needed|for garnish)\b`)
+)
+
+var table = loadTable()
+
+func loadTable() []entry {
+	nutrients := make([]*Nutrient, 0)
+	if err := json.Unmarshal(nutrientsJSON, &nutrients); err != nil {
+		panic("invalid embedded nutrient table: " + err.Error())
+	}
+
+	entries := make([]entry, 0, len(nutrients))
+	for _, nutrient := range nutrients {
+		for _, name := range append([]string{nutrient.Name}, nutrient.Aliases...) {
+			entries = append(entries, entry{nutrient: nutrient, tokens: ingredients.Tokens(name)})
+		}
+	}
+	return entries
+}
+
+// Lookup finds the most specific nutrient whose name or alias is contained in the given
+// ingredient name, so "peanut butter" wins over "butter".
+func Lookup(name string) (*Nutrient, bool) {
+	nameTokens := make(map[string]bool)
+	for _, token := range ingredients.Tokens(name) {
+		nameTokens[token] = true
+	}
+
+	var best *entry
+	for i := range table {
+		candidate := &table[i]
+		if len(candidate.tokens) == 0 || (best != nil && len(candidate.tokens) <= len(best.tokens)) {
+			continue
+		}
+		contained := true
+		for _, token := range candidate.tokens {
+			if !nameTokens[token] {
+				contained = false
+				break
+			}
+		}
+		if contained {
+			best = candidate
+		}
+	}
+	if best == nil {
+		return nil, false
+	}
+	return best.nutrient, true
+}
+
+// Estimate computes per serving nutrition for a list of ingredient lines. Lines that
+// cannot be matched to the nutrient table or converted to grams are reported as
+// unmatched and lower the confidence of the estimate.
+func Estimate(lines []string, servings int) models.Nutrition {
+	if servings < 1 {
+		servings = 1
+	}
+
+	estimate := models.Nutrition{Servings: servings, Unmatched: make([]string, 0)}
+	total, matched := 0, 0
+	for _, line := range lines {
+		ingredient := ingredients.Parse(line)
+		if len(ingredients.Tokens(ingredient.Name)) == 0 {
+			continue
+		}
+		total++
+		if ingredient.Quantity == 0 && negligible(ingredient) {
+			matched++
+			continue
+		}
+
+		nutrient, found := Lookup(ingredient.Name)
+		if !found {
+			estimate.Unmatched = append(estimate.Unmatched, ingredient.Name)
+			continue
+		}
+		weight, converted := grams(ingredient, nutrient)
+		if !converted {
+			estimate.Unmatched = append(estimate.Unmatched, ingredient.Name)
+			continue
+		}
+		matched++
+
+		factor := weight / 100 / float64(servings)
+		estimate.Calories += nutrient.Calories * factor
+		estimate.ProteinG += nutrient.ProteinG * factor
+		estimate.FatG += nutrient.FatG * factor
+		estimate.CarbsG += nutrient.CarbsG * factor
+		estimate.SodiumMg += nutrient.SodiumMg * factor
+	}
+
+	estimate.Calories = round(estimate.Calories)
+	estimate.ProteinG = round(estimate.ProteinG)
+	estimate.FatG = round(estimate.FatG)
+	estimate.CarbsG = round(estimate.CarbsG)
+	estimate.SodiumMg = round(estimate.SodiumMg)
+	estimate.Confidence = confidence(matched, total)
+	return estimate
+}
+
+// negligible reports whether an unmeasured ingredient is a seasoning, as in "kosher
+// salt", "salt and pepper" or "chili flakes, to taste".
+func negligible(ingredient ingredients.Ingredient) bool {
+	if toTasteRegex.MatchString(ingredient.Raw) {
+		return true
+	}
+	seasoning := false
+	for _, token := range ingredients.Tokens(ingredient.Name) {
+		switch {
+		case seasonings[token]:
+			seasoning = true
+		case !seasoningQualifiers[token]:
+			return false
+		}
+	}
+	return seasoning
+}
+
+func grams(ingredient ingredients.Ingredient, nutrient *Nutrient) (float64, bool) {
+	if ingredient.Quantity == 0 {
+		return 0, false
+	}
+
+	if weight, ok := gramsPerWeightUnit[ingredient.Unit]; ok {
+		return ingredient.Quantity * weight, true
+	}
+	if cups, ok := cupsPerVolumeUnit[ingredient.Unit]; ok {
+		if nutrient.GramsPerCup == 0 {
+			return 0, false
+		}
+		return ingredient.Quantity * cups * nutrient.GramsPerCup, true
+	}
+
+	unit := ingredient.Unit
+	if unit == "" {
+		unit = "piece"
+		// Counted units are sometimes written after the name, as in "2 garlic cloves".
+		for _, token := range ingredients.Tokens(ingredient.Name) {
+			if _, ok := nutrient.GramsPerUnit[token]; ok {
+				unit = token
+			}
+		}
+	}
+	weight, ok := nutrient.GramsPerUnit[unit]
+	if !ok {
+		return 0, false
+	}
+	return ingredient.Quantity * weight, true
+}
+
+func confidence(matched, total int) string {
+	if total == 0 {
+		return "low"
+	}
+	ratio := float64(matched) / float64(total)
+	switch {
+	case ratio >= 0.9:
+		return "high"
+	case ratio >= 0.7:
+		return "medium"
+	default:
+		return "low"
+	}
+}
+
+func round(value float64) float64 {
+	return math.Round(value*10) / 10
+}
@@ -0,0 +1,81 @@
+package nutrition
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestEstimate(t *testing.T) {
+	tests := []struct {
+		name       string
+		lines      []string
+		servings   int
+		calories   float64
+		confidence string
+		unmatched  []string
+	}{
+		{
+			name:       "measured",
+			lines:      []string{"2 tbsp butter", "1 cup all-purpose flour"},
+			servings:   2,
+			calories:   329.2,
+			confidence: "high",
+			unmatched:  []string{},
+		},
+		{
+			name:       "seasonings without a quantity",
+			lines:      []string{"1 cup all-purpose flour", "salt and pepper", "freshly ground black pepper", "chili flakes, to taste"},
+			servings:   1,
+			calories:   455,
+			confidence: "high",
+			unmatched:  []string{},
+		},
+		{
+			name:       "unmeasured ingredients",
+			lines:      []string{"chicken thighs", "olive oil", "butter"},
+			servings:   2,
+			calories:   0,
+			confidence: "low",
+			unmatched:  []string{"chicken thighs", "olive oil", "butter"},
+		},
+		{
+			name:       "unknown ingredient",
+			lines:      []string{"1 cup all-purpose flour", "2 tbsp butter", "1 cup dragon fruit puree"},
+			servings:   1,
+			calories:   658.4,
+			confidence: "low",
+			unmatched:  []string{"dragon fruit puree"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			estimate := Estimate(test.lines, test.servings)
+			if estimate.Calories != test.calories {
+				t.Errorf("calories = %v, want %v", estimate.Calories, test.calories)
+			}
+			if estimate.Confidence != test.confidence {
+				t.Errorf("confidence = %q, want %q", estimate.Confidence, test.confidence)
+			}
+			if !reflect.DeepEqual(estimate.Unmatched, test.unmatched) {
+				t.Errorf("unmatched = %q, want %q", estimate.Unmatched, test.unmatched)
+			}
+		})
+	}
+}
+
+func TestLookup(t *testing.T) {
+	tests := map[string]string{
+		"peanut butter":          "peanut butter",
+		"unsalted butter":        "butter",
+		"boneless chicken thigh": "chicken thigh",
+	}
+	for name, want := range tests {
+		nutrient, found := Lookup(name)
+		if !found || nutrient.Name != want {
+			t.Errorf("Lookup(%q) = %v, want %s", name, nutrient, want)
+		}
+	}
+	if nutrient, found := Lookup("dragon fruit"); found {
+		t.Errorf("Lookup(dragon fruit) = %s, want none", nutrient.Name)
+	}
+}
@@ -0,0 +1,84 @@
+package query
+
+import (
+	"strings"
+	"unicode"
+)
+
+type tokenKind int
+
+const (
+	tokenEnd tokenKind = iota
+	tokenTerm
+	tokenAnd
+	tokenOr
+	tokenNot
+	tokenOpen
+	tokenClose
+)
+
+// token is a lexical unit of a query. Column is the 1-based position of its first
+// character, used to point at it in errors.
+type token struct {
+	kind   tokenKind
+	text   string
+	column int
+}
+
+// lex splits a query into parentheses, the AND, OR and NOT keywords, a leading "-"
+// negating the next term, and terms. A term runs to the next space or parenthesis,
+// except inside double quotes.
+func lex(input string) ([]token, error) {
+	runes := []rune(input)
+	tokens := make([]token, 0)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '(':
+			tokens = append(tokens, token{kind: tokenOpen, text: "(", column: i + 1})
+			i++
+		case r == ')':
+			tokens = append(tokens, token{kind: tokenClose, text: ")", column: i + 1})
+			i++
+		case r == '-' && i+1 < len(runes) && !unicode.IsSpace(runes[i+1]) && runes[i+1] != ')':
+			tokens = append(tokens, token{kind: tokenNot, text: "-", column: i + 1})
+			i++
+		default:
+			start := i
+			quoted := false
+			for ; i < len(runes); i++ {
+				if runes[i] == '"' {
+					quoted = !quoted
+				} else if !quoted && (unicode.IsSpace(runes[i]) || runes[i] == '(' || runes[i] == ')') {
+					break
+				}
+			}
+			text := string(runes[start:i])
+			if quoted {
+				return nil, &SyntaxError{Message: "unterminated quote", Token: text, Column: start + 1}
+			}
+			tokens = append(tokens, token{kind: keyword(text), text: text, column: start + 1})
+		}
+	}
+	return append(tokens, token{kind: tokenEnd, column: len(runes) + 1}), nil
+}
+
+// keyword recognizes the operators, which must be written in upper case so that
+// "and" and "or" can still be searched for.
+func keyword(text string) tokenKind {
+	switch text {
+	case "AND":
+		return tokenAnd
+	case "OR":
+		return tokenOr
+	case "NOT":
+		return tokenNot
+	}
+	return tokenTerm
+}
+
+func unquote(value string) string {
+	return strings.TrimSpace(strings.ReplaceAll(value, `"`, ""))
+}
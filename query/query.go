@@ -0,0 +1,295 @@
+// Package query compiles search expressions such as
+//
+//	tag:dessert AND (ingredient:chocolate OR ingredient:cocoa) -nuts time<30m
+//
+// into MongoDB filters. Terms next to each other are combined with AND, which binds
+// tighter than OR. A leading "-" or NOT excludes a term or group.
+package query
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/mahesh-yadav/go-recipes-api/dietary"
+	"github.com/mahesh-yadav/go-recipes-api/isoduration"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// SyntaxError points at the token that could not be parsed.
+type SyntaxError struct {
+	Message string
+	Token   string
+	Column  int
+}
+
+func (e *SyntaxError) Error() string {
+	if e.Token == "" {
+		return fmt.Sprintf("%s at column %d", e.Message, e.Column)
+	}
+	return fmt.Sprintf("%s at column %d: %s", e.Message, e.Column, e.Token)
+}
+
+// Options customize how terms compile.
+type Options struct {
+	// ExpandTag returns the tags a tag: term matches, e.g. its canonical slug and
+	// child tags. The tag itself is matched when it is nil.
+	ExpandTag func(tag string) []string
+}
+
+// Fields lists the field names accepted before ":".
+var Fields = []string{"tag", "ingredient", "name", "cuisine", "course", "difficulty", "diet", "allergen", "equipment", "time"}
+
+var comparisons = []struct {
+	operator string
+	mongo    string
+}{
+	{"<=", "$lte"},
+	{">=", "$gte"},
+	{"<", "$lt"},
+	{">", "$gt"},
+}
+
+// Compile parses the expression and returns the filter matching it.
+func Compile(input string, options Options) (bson.D, error) {
+	tokens, err := lex(input)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{tokens: tokens, options: options}
+	if p.peek().kind == tokenEnd {
+		return nil, &SyntaxError{Message: "empty query", Column: 1}
+	}
+	filter, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if next := p.peek(); next.kind != tokenEnd {
+		return nil, p.unexpected(next)
+	}
+	return filter, nil
+}
+
+type parser struct {
+	tokens  []token
+	pos     int
+	options Options
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokenEnd {
+		p.pos++
+	}
+	return t
+}
+
+func (p *parser) unexpected(t token) error {
+	if t.kind == tokenEnd {
+		return &SyntaxError{Message: "unexpected end of query", Column: t.column}
+	}
+	return &SyntaxError{Message: "unexpected token", Token: t.text, Column: t.column}
+}
+
+// parseOr parses and-expressions separated by OR.
+func (p *parser) parseOr() (bson.D, error) {
+	clauses := make(bson.A, 0, 1)
+	for {
+		clause, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		clauses = append(clauses, clause)
+		if p.peek().kind != tokenOr {
+			break
+		}
+		p.next()
+	}
+	if len(clauses) == 1 {
+		return clauses[0].(bson.D), nil
+	}
+	return bson.D{{Key: "$or", Value: clauses}}, nil
+}
+
+// parseAnd parses unary expressions separated by AND or nothing at all.
+func (p *parser) parseAnd() (bson.D, error) {
+	clauses := make(bson.A, 0, 1)
+	for {
+		clause, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		clauses = append(clauses, clause)
+
+		switch p.peek().kind {
+		case tokenAnd:
+			p.next()
+			continue
+		case tokenTerm, tokenNot, tokenOpen:
+			continue
+		}
+		break
+	}
+	if len(clauses) == 1 {
+		return clauses[0].(bson.D), nil
+	}
+	return bson.D{{Key: "$and", Value: clauses}}, nil
+}
+
+func (p *parser) parseUnary() (bson.D, error) {
+	if p.peek().kind == tokenNot {
+		p.next()
+		clause, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return bson.D{{Key: "$nor", Value: bson.A{clause}}}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *parser) parsePrimary() (bson.D, error) {
+	t := p.next()
+	switch t.kind {
+	case tokenOpen:
+		filter, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if p.peek().kind != tokenClose {
+			return nil, &SyntaxError{Message: "unclosed parenthesis", Token: "(", Column: t.column}
+		}
+		p.next()
+		return filter, nil
+	case tokenTerm:
+		if t.text == "-" {
+			return nil, p.unexpected(t)
+		}
+		return p.compileTerm(t)
+	}
+	return nil, p.unexpected(t)
+}
+
+// compileTerm compiles a bare word or phrase, a field:value pair or a time comparison.
+func (p *parser) compileTerm(t token) (bson.D, error) {
+	field, operator, value := splitTerm(t.text)
+	if operator == "" {
+		phrase := unquote(t.text)
+		if phrase == "" {
+			return nil, &SyntaxError{Message: "empty phrase", Token: t.text, Column: t.column}
+		}
+		pattern := wordPattern(phrase)
+		return bson.D{{Key: "$or", Value: bson.A{
+			bson.D{{Key: "name", Value: pattern}},
+			bson.D{{Key: "ingredients", Value: pattern}},
+			bson.D{{Key: "tags", Value: exactPattern(phrase)}},
+		}}}, nil
+	}
+
+	field = strings.ToLower(field)
+	value = unquote(value)
+	if value == "" {
+		return nil, &SyntaxError{Message: "missing value", Token: t.text, Column: t.column}
+	}
+	if field == "time" {
+		return compileTime(t, operator, value)
+	}
+	if operator != ":" {
+		return nil, &SyntaxError{Message: fmt.Sprintf("%s only supports ':'", field), Token: t.text, Column: t.column}
+	}
+
+	switch field {
+	case "tag":
+		tags := []string{value}
+		if p.options.ExpandTag != nil {
+			tags = p.options.ExpandTag(value)
+		}
+		return bson.D{{Key: "tags", Value: bson.D{{Key: "$in", Value: tags}}}}, nil
+	case "ingredient":
+		return bson.D{{Key: "ingredients", Value: wordPattern(value)}}, nil
+	case "name":
+		return bson.D{{Key: "name", Value: wordPattern(value)}}, nil
+	case "equipment":
+		return bson.D{{Key: "equipment", Value: wordPattern(value)}}, nil
+	case "cuisine", "course", "difficulty":
+		return bson.D{{Key: field, Value: exactPattern(value)}}, nil
+	case "diet":
+		if !dietary.IsDiet(value) {
+			return nil, &SyntaxError{Message: "unknown diet, expected one of " + strings.Join(dietary.Diets(), ", "), Token: t.text, Column: t.column}
+		}
+		return bson.D{{Key: "diets", Value: value}}, nil
+	case "allergen":
+		if !dietary.IsAllergen(value) {
+			return nil, &SyntaxError{Message: "unknown allergen, expected one of " + strings.Join(dietary.Allergens(), ", "), Token: t.text, Column: t.column}
+		}
+		return bson.D{{Key: "allergens", Value: value}}, nil
+	}
+	return nil, &SyntaxError{Message: "unknown field, expected one of " + strings.Join(Fields, ", "), Token: t.text, Column: t.column}
+}
+
+// compileTime compares the total time with a duration such as 30m or PT30M. Recipes
+// without a total time never match.
+func compileTime(t token, operator string, value string) (bson.D, error) {
+	mongoOperator := ""
+	for _, comparison := range comparisons {
+		if comparison.operator == operator {
+			mongoOperator = comparison.mongo
+		}
+	}
+	if mongoOperator == "" {
+		return nil, &SyntaxError{Message: "time needs a comparison such as time<30m", Token: t.text, Column: t.column}
+	}
+
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		duration, err = isoduration.Parse(value)
+	}
+	if err != nil || duration <= 0 {
+		return nil, &SyntaxError{Message: "invalid duration, expected e.g. 30m or PT30M", Token: t.text, Column: t.column}
+	}
+
+	return bson.D{{Key: "total_time_minutes", Value: bson.D{
+		{Key: "$gt", Value: 0},
+		{Key: mongoOperator, Value: int(duration.Minutes())},
+	}}}, nil
+}
+
+// splitTerm splits field:value and field<value terms. Terms starting with a quote
+// are phrases and have no field.
+func splitTerm(text string) (field string, operator string, value string) {
+	if strings.HasPrefix(text, `"`) {
+		return "", "", text
+	}
+	i := strings.IndexAny(text, ":<>")
+	if i <= 0 {
+		return "", "", text
+	}
+	field, rest := text[:i], text[i:]
+	if strings.ContainsRune(field, '"') {
+		return "", "", text
+	}
+	// time:<30m reads as time<30m.
+	if strings.HasPrefix(rest, ":<") || strings.HasPrefix(rest, ":>") {
+		rest = rest[1:]
+	}
+	for _, comparison := range comparisons {
+		if strings.HasPrefix(rest, comparison.operator) {
+			return field, comparison.operator, rest[len(comparison.operator):]
+		}
+	}
+	return field, ":", rest[1:]
+}
+
+// wordPattern matches values containing the phrase at the start of a word.
+func wordPattern(phrase string) bson.Regex {
+	return bson.Regex{Pattern: `\b` + regexp.QuoteMeta(phrase), Options: "i"}
+}
+
+func exactPattern(value string) bson.Regex {
+	return bson.Regex{Pattern: "^" + regexp.QuoteMeta(value) + "$", Options: "i"}
+}
@@ -0,0 +1,141 @@
+package query
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+func word(phrase string) bson.D {
+	return bson.D{{Key: "$or", Value: bson.A{
+		bson.D{{Key: "name", Value: wordPattern(phrase)}},
+		bson.D{{Key: "ingredients", Value: wordPattern(phrase)}},
+		bson.D{{Key: "tags", Value: exactPattern(phrase)}},
+	}}}
+}
+
+func tag(tags ...string) bson.D {
+	return bson.D{{Key: "tags", Value: bson.D{{Key: "$in", Value: tags}}}}
+}
+
+func ingredient(value string) bson.D {
+	return bson.D{{Key: "ingredients", Value: wordPattern(value)}}
+}
+
+func and(clauses ...any) bson.D {
+	return bson.D{{Key: "$and", Value: bson.A(clauses)}}
+}
+
+func or(clauses ...any) bson.D {
+	return bson.D{{Key: "$or", Value: bson.A(clauses)}}
+}
+
+func not(clause bson.D) bson.D {
+	return bson.D{{Key: "$nor", Value: bson.A{clause}}}
+}
+
+func totalTime(operator string, minutes int) bson.D {
+	return bson.D{{Key: "total_time_minutes", Value: bson.D{
+		{Key: "$gt", Value: 0},
+		{Key: operator, Value: minutes},
+	}}}
+}
+
+func TestCompile(t *testing.T) {
+	tests := []struct {
+		input string
+		want  bson.D
+	}{
+		{input: "chocolate", want: word("chocolate")},
+		{input: "chocolate cake", want: and(word("chocolate"), word("cake"))},
+		{input: "chocolate AND cake", want: and(word("chocolate"), word("cake"))},
+		{input: "a OR b c", want: or(word("a"), and(word("b"), word("c")))},
+		{input: "a b OR c", want: or(and(word("a"), word("b")), word("c"))},
+		{input: "(a OR b) c", want: and(or(word("a"), word("b")), word("c"))},
+		{input: "-nuts", want: not(word("nuts"))},
+		{input: "NOT nuts", want: not(word("nuts"))},
+		{input: "cake -(nuts OR raisins)", want: and(word("cake"), not(or(word("nuts"), word("raisins"))))},
+		{input: "and or", want: and(word("and"), word("or"))},
+		{input: "gluten-free", want: word("gluten-free")},
+		{input: `"olive oil"`, want: word("olive oil")},
+		{input: `ingredient:"olive oil"`, want: ingredient("olive oil")},
+		{input: "tag:dessert", want: tag("dessert")},
+		{input: "time<30m", want: totalTime("$lt", 30)},
+		{input: "time:<=PT1H", want: totalTime("$lte", 60)},
+		{input: "time>=1h30m", want: totalTime("$gte", 90)},
+		{input: "cuisine:Italian", want: bson.D{{Key: "cuisine", Value: exactPattern("Italian")}}},
+		{input: "diet:vegan", want: bson.D{{Key: "diets", Value: "vegan"}}},
+		{
+			input: "tag:dessert AND (ingredient:chocolate OR ingredient:cocoa) -nuts time<30m",
+			want: and(
+				tag("dessert"),
+				or(ingredient("chocolate"), ingredient("cocoa")),
+				not(word("nuts")),
+				totalTime("$lt", 30),
+			),
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			got, err := Compile(test.input, Options{})
+			if err != nil {
+				t.Fatalf("Compile(%q) error = %v", test.input, err)
+			}
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("Compile(%q) = %v, want %v", test.input, got, test.want)
+			}
+		})
+	}
+}
+
+func TestCompileExpandTag(t *testing.T) {
+	options := Options{ExpandTag: func(tag string) []string {
+		return []string{tag, "chicken"}
+	}}
+	got, err := Compile("tag:poultry", options)
+	if err != nil {
+		t.Fatalf("Compile() error = %v", err)
+	}
+	if want := tag("poultry", "chicken"); !reflect.DeepEqual(got, want) {
+		t.Errorf("Compile() = %v, want %v", got, want)
+	}
+}
+
+func TestCompileErrors(t *testing.T) {
+	tests := []struct {
+		input  string
+		token  string
+		column int
+	}{
+		{input: "", column: 1},
+		{input: "   ", column: 1},
+		{input: "cake AND", column: 9},
+		{input: "OR cake", token: "OR", column: 1},
+		{input: "(cake", token: "(", column: 1},
+		{input: "cake)", token: ")", column: 5},
+		{input: `"olive oil`, token: `"olive oil`, column: 1},
+		{input: `cake ""`, token: `""`, column: 6},
+		{input: "colour:red", token: "colour:red", column: 1},
+		{input: "tag:", token: "tag:", column: 1},
+		{input: "time:30m", token: "time:30m", column: 1},
+		{input: "time<soon", token: "time<soon", column: 1},
+		{input: "time<0m", token: "time<0m", column: 1},
+		{input: "name<cake", token: "name<cake", column: 1},
+		{input: "cake diet:carnivore", token: "diet:carnivore", column: 6},
+		{input: "allergen:gravel", token: "allergen:gravel", column: 1},
+	}
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			_, err := Compile(test.input, Options{})
+			var syntaxError *SyntaxError
+			if !errors.As(err, &syntaxError) {
+				t.Fatalf("Compile(%q) error = %v, want a SyntaxError", test.input, err)
+			}
+			if syntaxError.Token != test.token || syntaxError.Column != test.column {
+				t.Errorf("Compile(%q) error at %q, column %d, want %q, column %d", test.input, syntaxError.Token, syntaxError.Column, test.token, test.column)
+			}
+		})
+	}
+}
@@ -0,0 +1,168 @@
+package schemaorg
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mahesh-yadav/go-recipes-api/models"
+)
+
+// Recipe is a schema.org Recipe in JSON-LD.
+type Recipe struct {
+	Context            string                `json:"@context"`
+	Type               string                `json:"@type"`
+	Name               string                `json:"name"`
+	Image              []string              `json:"image,omitempty"`
+	Author             *Thing                `json:"author,omitempty"`
+	DatePublished      string                `json:"datePublished,omitempty"`
+	DateModified       string                `json:"dateModified,omitempty"`
+	InLanguage         string                `json:"inLanguage,omitempty"`
+	Keywords           string                `json:"keywords,omitempty"`
+	RecipeCategory     string                `json:"recipeCategory,omitempty"`
+	RecipeCuisine      string                `json:"recipeCuisine,omitempty"`
+	RecipeYield        []string              `json:"recipeYield,omitempty"`
+	PrepTime           string                `json:"prepTime,omitempty"`
+	CookTime           string                `json:"cookTime,omitempty"`
+	TotalTime          string                `json:"totalTime,omitempty"`
+	Tool               []Thing               `json:"tool,omitempty"`
+	RecipeIngredient   []string              `json:"recipeIngredient"`
+	RecipeInstructions []any                 `json:"recipeInstructions"`
+	Nutrition          *NutritionInformation `json:"nutrition,omitempty"`
+	SuitableForDiet    []string              `json:"suitableForDiet,omitempty"`
+	IsBasedOn          string                `json:"isBasedOn,omitempty"`
+}
+
+// Thing is a named schema.org entity, such as a Person or a HowToTool.
+type Thing struct {
+	Type string `json:"@type"`
+	Name string `json:"name"`
+}
+
+type HowToStep struct {
+	Type string `json:"@type"`
+	Text string `json:"text"`
+}
+
+type HowToSection struct {
+	Type            string      `json:"@type"`
+	Name            string      `json:"name"`
+	ItemListElement []HowToStep `json:"itemListElement"`
+}
+
+type NutritionInformation struct {
+	Type                string `json:"@type"`
+	Calories            string `json:"calories"`
+	ProteinContent      string `json:"proteinContent"`
+	FatContent          string `json:"fatContent"`
+	CarbohydrateContent string `json:"carbohydrateContent"`
+	SodiumContent       string `json:"sodiumContent"`
+}
+
+// diets maps dietary labels to the schema.org RestrictedDiet values that exist.
+var diets = map[string]string{
+	"vegan":       "https://schema.org/VeganDiet",
+	"vegetarian":  "https://schema.org/VegetarianDiet",
+	"gluten-free": "https://schema.org/GlutenFreeDiet",
+}
+
+// FromRecipe describes a recipe as a schema.org Recipe.
+func FromRecipe(recipe models.ViewRecipe) Recipe {
+	output := Recipe{
+		Context:            "https://schema.org",
+		Type:               "Recipe",
+		Name:               recipe.Name,
+		InLanguage:         recipe.Locale,
+		Keywords:           strings.Join(recipe.Tags, ", "),
+		RecipeCategory:     recipe.Course,
+		RecipeCuisine:      recipe.Cuisine,
+		PrepTime:           recipe.PrepTime,
+		CookTime:           recipe.CookTime,
+		TotalTime:          recipe.TotalTime,
+		RecipeIngredient:   recipe.Ingredients,
+		RecipeInstructions: make([]any, 0, len(recipe.Instructions)),
+	}
+	if output.RecipeIngredient == nil {
+		output.RecipeIngredient = make([]string, 0)
+	}
+
+	for _, image := range recipe.Images {
+		if url := ImageURL(image); url != "" {
+			output.Image = append(output.Image, url)
+		}
+	}
+
+	author := recipe.Author
+	if recipe.Attribution != nil {
+		if recipe.Attribution.Author != "" {
+			author = recipe.Attribution.Author
+		}
+		output.IsBasedOn = recipe.Attribution.URL
+	}
+	if author != "" {
+		output.Author = &Thing{Type: "Person", Name: author}
+	}
+
+	if recipe.PublishedAt != nil {
+		output.DatePublished = recipe.PublishedAt.Format(time.RFC3339)
+	}
+	if recipe.UpdatedAt != nil {
+		output.DateModified = recipe.UpdatedAt.Format(time.RFC3339)
+	}
+
+	if recipe.Servings > 0 {
+		output.RecipeYield = append(output.RecipeYield, fmt.Sprint(recipe.Servings))
+	}
+	if recipe.Yield != "" {
+		output.RecipeYield = append(output.RecipeYield, recipe.Yield)
+	}
+
+	for _, equipment := range recipe.Equipment {
+		output.Tool = append(output.Tool, Thing{Type: "HowToTool", Name: equipment})
+	}
+
+	// Consecutive steps of the same section are grouped into a HowToSection.
+	var section *HowToSection
+	for _, step := range recipe.Instructions {
+		howToStep := HowToStep{Type: "HowToStep", Text: step.Text}
+		if step.Section == "" {
+			section = nil
+			output.RecipeInstructions = append(output.RecipeInstructions, howToStep)
+			continue
+		}
+		if section == nil || section.Name != step.Section {
+			output.RecipeInstructions = append(output.RecipeInstructions, &HowToSection{Type: "HowToSection", Name: step.Section})
+			section = output.RecipeInstructions[len(output.RecipeInstructions)-1].(*HowToSection)
+		}
+		section.ItemListElement = append(section.ItemListElement, howToStep)
+	}
+
+	if nutrition := recipe.Nutrition; nutrition != nil && nutrition.Calories > 0 {
+		output.Nutrition = &NutritionInformation{
+			Type:                "NutritionInformation",
+			Calories:            fmt.Sprintf("%.0f calories", nutrition.Calories),
+			ProteinContent:      fmt.Sprintf("%.1f g", nutrition.ProteinG),
+			FatContent:          fmt.Sprintf("%.1f g", nutrition.FatG),
+			CarbohydrateContent: fmt.Sprintf("%.1f g", nutrition.CarbsG),
+			SodiumContent:       fmt.Sprintf("%.0f mg", nutrition.SodiumMg),
+		}
+	}
+
+	for _, diet := range recipe.Diets {
+		if url, ok := diets[diet]; ok {
+			output.SuitableForDiet = append(output.SuitableForDiet, url)
+		}
+	}
+
+	return output
+}
+
+// ImageURL returns the URL of the largest rendition of an image.
+func ImageURL(image models.RecipeImage) string {
+	for _, size := range []string{"large", "medium", "original", "small"} {
+		if url, ok := image.URLs[size]; ok {
+			return url
+		}
+	}
+	return ""
+}
@@ -0,0 +1,168 @@
+// Package schemaorg converts between recipes and the schema.org Recipe vocabulary
+// used by recipe sites for search engines.
+package schemaorg
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+var ErrNoRecipe = errors.New("no schema.org Recipe found")
+
+// Extract returns the first schema.org Recipe of an HTML document, read from its
+// JSON-LD scripts or, failing that, its microdata, as a JSON-LD style object.
+func Extract(r io.Reader) (map[string]any, error) {
+	document, err := html.Parse(r)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, script := range findAll(document, func(node *html.Node) bool {
+		return node.DataAtom == atom.Script && strings.EqualFold(attribute(node, "type"), "application/ld+json")
+	}) {
+		var data any
+		if json.Unmarshal([]byte(textContent(script)), &data) != nil {
+			continue
+		}
+		if recipe := findRecipe(data); recipe != nil {
+			return recipe, nil
+		}
+	}
+
+	scopes := findAll(document, func(node *html.Node) bool {
+		return hasAttribute(node, "itemscope") && isRecipeType(attribute(node, "itemtype"))
+	})
+	if len(scopes) == 0 {
+		return nil, ErrNoRecipe
+	}
+	return microdata(scopes[0]), nil
+}
+
+// findRecipe searches JSON-LD for an object typed Recipe, looking into arrays and
+// @graph lists.
+func findRecipe(data any) map[string]any {
+	switch value := data.(type) {
+	case []any:
+		for _, item := range value {
+			if recipe := findRecipe(item); recipe != nil {
+				return recipe
+			}
+		}
+	case map[string]any:
+		for _, kind := range list(value["@type"]) {
+			if isRecipeType(text(kind)) {
+				return value
+			}
+		}
+		if graph, ok := value["@graph"]; ok {
+			return findRecipe(graph)
+		}
+	}
+	return nil
+}
+
+// isRecipeType accepts "Recipe" as well as full type URLs.
+func isRecipeType(kind string) bool {
+	for _, field := range strings.Fields(kind) {
+		field = strings.TrimSuffix(field, "/")
+		if field == "Recipe" || strings.HasSuffix(field, "schema.org/Recipe") {
+			return true
+		}
+	}
+	return false
+}
+
+// microdata collects the itemprop values within an itemscope. Values of nested
+// itemscopes, such as an author or a HowToStep, become nested objects.
+func microdata(scope *html.Node) map[string]any {
+	item := map[string]any{"@type": attribute(scope, "itemtype")}
+	var collect func(*html.Node)
+	collect = func(node *html.Node) {
+		for child := node.FirstChild; child != nil; child = child.NextSibling {
+			if child.Type != html.ElementNode {
+				continue
+			}
+			nested := hasAttribute(child, "itemscope")
+			for _, name := range strings.Fields(attribute(child, "itemprop")) {
+				var value any
+				if nested {
+					value = microdata(child)
+				} else {
+					value = propertyValue(child)
+				}
+				item[name] = append(list(item[name]), value)
+			}
+			if !nested {
+				collect(child)
+			}
+		}
+	}
+	collect(scope)
+	return item
+}
+
+func propertyValue(node *html.Node) string {
+	switch {
+	case hasAttribute(node, "content"):
+		return attribute(node, "content")
+	case node.DataAtom == atom.A || node.DataAtom == atom.Link:
+		return attribute(node, "href")
+	case node.DataAtom == atom.Img || node.DataAtom == atom.Source:
+		return attribute(node, "src")
+	case node.DataAtom == atom.Time && hasAttribute(node, "datetime"):
+		return attribute(node, "datetime")
+	case node.DataAtom == atom.Data || node.DataAtom == atom.Meter:
+		return attribute(node, "value")
+	}
+	return textContent(node)
+}
+
+// findAll returns the elements matching in document order.
+func findAll(node *html.Node, match func(*html.Node) bool) []*html.Node {
+	found := make([]*html.Node, 0)
+	if node.Type == html.ElementNode && match(node) {
+		found = append(found, node)
+	}
+	for child := node.FirstChild; child != nil; child = child.NextSibling {
+		found = append(found, findAll(child, match)...)
+	}
+	return found
+}
+
+func attribute(node *html.Node, name string) string {
+	for _, attr := range node.Attr {
+		if strings.EqualFold(attr.Key, name) {
+			return attr.Val
+		}
+	}
+	return ""
+}
+
+func hasAttribute(node *html.Node, name string) bool {
+	for _, attr := range node.Attr {
+		if strings.EqualFold(attr.Key, name) {
+			return true
+		}
+	}
+	return false
+}
+
+func textContent(node *html.Node) string {
+	var builder strings.Builder
+	var collect func(*html.Node)
+	collect = func(node *html.Node) {
+		if node.Type == html.TextNode {
+			builder.WriteString(node.Data)
+		}
+		for child := node.FirstChild; child != nil; child = child.NextSibling {
+			collect(child)
+		}
+	}
+	collect(node)
+	return builder.String()
+}
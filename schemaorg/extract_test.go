@@ -0,0 +1,45 @@
+package schemaorg
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func extractFixture(t *testing.T, name string) (map[string]any, error) {
+	t.Helper()
+	file, err := os.Open(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	return Extract(file)
+}
+
+func TestExtract(t *testing.T) {
+	tests := []struct {
+		fixture string
+		name    string
+		err     error
+	}{
+		{fixture: "jsonld.html", name: "Chocolate Chip Cookies"},
+		{fixture: "graph.html", name: "Lasagna"},
+		{fixture: "microdata.html", name: "Tomato Soup"},
+		{fixture: "none.html", err: ErrNoRecipe},
+	}
+	for _, test := range tests {
+		t.Run(test.fixture, func(t *testing.T) {
+			recipe, err := extractFixture(t, test.fixture)
+			if !errors.Is(err, test.err) {
+				t.Fatalf("error = %v, want %v", err, test.err)
+			}
+			if err != nil {
+				return
+			}
+			if name := text(first(recipe["name"])); name != test.name {
+				t.Errorf("name = %q, want %q", name, test.name)
+			}
+		})
+	}
+}
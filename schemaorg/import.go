@@ -0,0 +1,223 @@
+package schemaorg
+
+import (
+	"fmt"
+	"html"
+	"net/url"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/mahesh-yadav/go-recipes-api/isoduration"
+	"github.com/mahesh-yadav/go-recipes-api/models"
+)
+
+// Courses are the recipe courses; other recipe categories become tags.
+var Courses = []string{"breakfast", "brunch", "lunch", "dinner", "appetizer", "main", "side", "dessert", "snack", "drink"}
+
+var (
+	tagPattern    = regexp.MustCompile(`<[^>]*>`)
+	numberPattern = regexp.MustCompile(`\d+`)
+)
+
+// ToRecipe converts a schema.org Recipe to recipe input. sourceURL, when known, is
+// the page the recipe was found on and is credited in the attribution.
+func ToRecipe(recipe map[string]any, sourceURL string) models.AddUpdateRecipe {
+	input := models.AddUpdateRecipe{
+		Name:         text(first(recipe["name"])),
+		Tags:         make([]string, 0),
+		Ingredients:  make([]string, 0),
+		Instructions: instructions(recipe["recipeInstructions"]),
+		Cuisine:      strings.ToLower(text(first(recipe["recipeCuisine"]))),
+		PrepTime:     duration(recipe["prepTime"]),
+		CookTime:     duration(recipe["cookTime"]),
+		TotalTime:    duration(recipe["totalTime"]),
+		Equipment:    names(recipe["tool"]),
+	}
+
+	ingredients := recipe["recipeIngredient"]
+	if ingredients == nil {
+		ingredients = recipe["ingredients"]
+	}
+	for _, ingredient := range list(ingredients) {
+		if line := text(ingredient); line != "" {
+			input.Ingredients = append(input.Ingredients, line)
+		}
+	}
+
+	for _, category := range splitKeywords(recipe["recipeCategory"]) {
+		if course := courseOf(category); course != "" && input.Course == "" {
+			input.Course = course
+		} else {
+			input.Tags = appendTag(input.Tags, category)
+		}
+	}
+	for _, keyword := range splitKeywords(recipe["keywords"]) {
+		input.Tags = appendTag(input.Tags, keyword)
+	}
+
+	for _, value := range list(recipe["recipeYield"]) {
+		yield := text(value)
+		if yield == "" {
+			continue
+		}
+		if number := numberPattern.FindString(yield); number != "" && input.Servings == 0 {
+			input.Servings, _ = strconv.Atoi(number)
+		}
+		if input.Yield == "" || numberPattern.FindString(input.Yield) == input.Yield {
+			input.Yield = yield
+		}
+	}
+
+	attribution := models.Attribution{}
+	if authors := names(recipe["author"]); len(authors) > 0 {
+		attribution.Author = authors[0]
+	}
+	if publisher := names(recipe["publisher"]); len(publisher) > 0 {
+		attribution.Source = publisher[0]
+	}
+	attribution.URL = sourceURL
+	if attribution.URL == "" {
+		attribution.URL = text(first(recipe["url"]))
+	}
+	if parsed, err := url.Parse(attribution.URL); err == nil && attribution.Source == "" {
+		attribution.Source = strings.TrimPrefix(parsed.Hostname(), "www.")
+	}
+	if attribution != (models.Attribution{}) {
+		input.Attribution = &attribution
+	}
+
+	return input
+}
+
+// instructions accepts a block of text, a list of strings, HowToSteps and
+// HowToSections holding steps.
+func instructions(value any) models.Instructions {
+	steps := make(models.Instructions, 0)
+	var add func(value any, section string)
+	add = func(value any, section string) {
+		switch item := value.(type) {
+		case string:
+			for _, line := range strings.Split(item, "\n") {
+				if line := text(line); line != "" {
+					steps = append(steps, models.Step{Section: section, Text: line})
+				}
+			}
+		case map[string]any:
+			if elements, ok := item["itemListElement"]; ok {
+				name := text(first(item["name"]))
+				for _, element := range list(elements) {
+					add(element, name)
+				}
+				return
+			}
+			stepText := text(first(item["text"]))
+			if stepText == "" {
+				stepText = text(first(item["name"]))
+			}
+			if stepText != "" {
+				steps = append(steps, models.Step{Section: section, Text: stepText})
+			}
+		case []any:
+			for _, element := range item {
+				add(element, section)
+			}
+		}
+	}
+	add(value, "")
+	return steps
+}
+
+// duration returns the ISO-8601 duration, dropping values that are not one.
+func duration(value any) string {
+	duration := strings.ToUpper(text(first(value)))
+	if _, err := isoduration.Parse(duration); err != nil {
+		return ""
+	}
+	return duration
+}
+
+// names returns the names of people, organizations or tools given either as text
+// or as objects with a name.
+func names(value any) []string {
+	names := make([]string, 0)
+	for _, item := range list(value) {
+		var name string
+		if object, ok := item.(map[string]any); ok {
+			name = text(first(object["name"]))
+		} else {
+			name = text(item)
+		}
+		if name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// splitKeywords splits comma separated keyword text and lists of keywords.
+func splitKeywords(value any) []string {
+	keywords := make([]string, 0)
+	for _, item := range list(value) {
+		for _, keyword := range strings.Split(text(item), ",") {
+			if keyword = strings.TrimSpace(keyword); keyword != "" {
+				keywords = append(keywords, keyword)
+			}
+		}
+	}
+	return keywords
+}
+
+func courseOf(category string) string {
+	category = strings.ToLower(category)
+	for _, suffix := range []string{"", " course", " dish", "s"} {
+		if course := strings.TrimSuffix(category, suffix); slices.Contains(Courses, course) {
+			return course
+		}
+	}
+	return ""
+}
+
+func appendTag(tags []string, tag string) []string {
+	tag = strings.ToLower(tag)
+	if slices.Contains(tags, tag) {
+		return tags
+	}
+	return append(tags, tag)
+}
+
+// list returns a value as a list, so single values and arrays are read alike.
+func list(value any) []any {
+	switch value := value.(type) {
+	case nil:
+		return nil
+	case []any:
+		return value
+	}
+	return []any{value}
+}
+
+func first(value any) any {
+	if values := list(value); len(values) > 0 {
+		return values[0]
+	}
+	return nil
+}
+
+// text returns a value as plain text, without markup, entities or extra spaces.
+func text(value any) string {
+	var raw string
+	switch value := value.(type) {
+	case string:
+		raw = value
+	case float64:
+		raw = strconv.FormatFloat(value, 'f', -1, 64)
+	case nil:
+		return ""
+	default:
+		raw = fmt.Sprint(value)
+	}
+	raw = html.UnescapeString(tagPattern.ReplaceAllString(raw, " "))
+	return strings.Join(strings.Fields(raw), " ")
+}
@@ -0,0 +1,102 @@
+package schemaorg
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/mahesh-yadav/go-recipes-api/models"
+)
+
+func TestToRecipe(t *testing.T) {
+	tests := []struct {
+		fixture   string
+		sourceURL string
+		want      models.AddUpdateRecipe
+	}{
+		{
+			fixture:   "jsonld.html",
+			sourceURL: "https://www.cookies.example/chocolate-chip",
+			want: models.AddUpdateRecipe{
+				Name: "Chocolate Chip Cookies",
+				Tags: []string{"cookies", "baking", "chocolate"},
+				Ingredients: []string{
+					"2 1/4 cups all-purpose flour",
+					"1 cup butter, softened",
+					"2 cups chocolate chips",
+				},
+				Instructions: models.Instructions{
+					{Text: "Preheat the oven to 375°F."},
+					{Text: "Mix the flour and butter."},
+					{Text: "Stir in the chips and bake for 10 minutes."},
+				},
+				Servings:  24,
+				Yield:     "24 cookies",
+				PrepTime:  "PT15M",
+				CookTime:  "PT10M",
+				Cuisine:   "american",
+				Course:    "dessert",
+				Equipment: []string{},
+				Attribution: &models.Attribution{
+					Source: "cookies.example",
+					Author: "Ruth Wakefield",
+					URL:    "https://www.cookies.example/chocolate-chip",
+				},
+			},
+		},
+		{
+			fixture: "graph.html",
+			want: models.AddUpdateRecipe{
+				Name:        "Lasagna",
+				Tags:        []string{"dinner", "pasta"},
+				Ingredients: []string{"12 lasagna noodles", "2 cups ricotta", "3 cups marinara sauce"},
+				Instructions: models.Instructions{
+					{Section: "Sauce", Text: "Warm the marinara sauce."},
+					{Section: "Assembly", Text: "Layer noodles, ricotta and sauce."},
+					{Section: "Assembly", Text: "Bake for 45 minutes."},
+				},
+				Servings:  8,
+				Yield:     "8",
+				Course:    "main",
+				Equipment: []string{"Baking dish", "Saucepan"},
+				Attribution: &models.Attribution{
+					Source: "Weeknight Kitchen",
+					Author: "Ana",
+					URL:    "https://www.weeknight.example/lasagna",
+				},
+			},
+		},
+		{
+			fixture: "microdata.html",
+			want: models.AddUpdateRecipe{
+				Name:        "Tomato Soup",
+				Tags:        []string{},
+				Ingredients: []string{"1 kg tomatoes", "1 onion", "500 ml stock"},
+				Instructions: models.Instructions{
+					{Text: "Chop the tomatoes and onion."},
+					{Text: "Simmer in the stock for 30 minutes."},
+				},
+				Servings:  4,
+				Yield:     "4 bowls",
+				TotalTime: "PT40M",
+				Cuisine:   "italian",
+				Equipment: []string{},
+				Attribution: &models.Attribution{
+					Source: "soups.example",
+					Author: "Jane Doe",
+					URL:    "https://soups.example/tomato",
+				},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.fixture, func(t *testing.T) {
+			recipe, err := extractFixture(t, test.fixture)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := ToRecipe(recipe, test.sourceURL); !reflect.DeepEqual(got, test.want) {
+				t.Errorf("ToRecipe() = %+v\nwant %+v", got, test.want)
+			}
+		})
+	}
+}
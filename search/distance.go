@@ -0,0 +1,68 @@
+package search
+
+// Distance returns the optimal string alignment distance between a and b: the number
+// of insertions, deletions, substitutions and transpositions of adjacent runes needed
+// to turn one into the other.
+func Distance(a, b string) int {
+	t := []rune(b)
+	return alignment([]rune(a), t, len(t))
+}
+
+// PrefixDistance returns the smallest distance between query and a prefix of word, so
+// that a partially typed word with typos still matches.
+func PrefixDistance(query, word string) int {
+	s, t := []rune(query), []rune(word)
+	// Prefixes longer than the query plus two typos cannot be any closer.
+	if len(t) > len(s)+2 {
+		t = t[:len(s)+2]
+	}
+	return alignment(s, t, -1)
+}
+
+// alignment computes the distance between s and t[:end], or between s and its closest
+// prefix of t when end is negative.
+func alignment(s, t []rune, end int) int {
+	// Three rows are enough, transpositions only look two rows back.
+	previous2 := make([]int, len(t)+1)
+	previous := make([]int, len(t)+1)
+	current := make([]int, len(t)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(s); i++ {
+		current[0] = i
+		for j := 1; j <= len(t); j++ {
+			cost := 1
+			if s[i-1] == t[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+			if i > 1 && j > 1 && s[i-1] == t[j-2] && s[i-2] == t[j-1] {
+				current[j] = min(current[j], previous2[j-2]+1)
+			}
+		}
+		previous2, previous, current = previous, current, previous2
+	}
+
+	if end >= 0 {
+		return previous[end]
+	}
+	best := previous[0]
+	for _, distance := range previous {
+		best = min(best, distance)
+	}
+	return best
+}
+
+// MaxTypos is the number of typos tolerated in a word: none for very short words,
+// one up to six letters, two beyond.
+func MaxTypos(word string) int {
+	switch length := len([]rune(word)); {
+	case length < 3:
+		return 0
+	case length <= 6:
+		return 1
+	default:
+		return 2
+	}
+}
@@ -0,0 +1,237 @@
+package search
+
+import (
+	"context"
+	"encoding/gob"
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// saveDelay batches the saves of changes arriving close together, since each save
+// rewrites the whole file. A crash loses at most this much of the latest changes,
+// which a reindex restores.
+const saveDelay = 2 * time.Second
+
+// EmbeddedIndex is an in-process inverted index from name and ingredient words to
+// documents. It is saved to a file shortly after changes and loaded from it on start.
+type EmbeddedIndex struct {
+	mu        sync.RWMutex
+	path      string
+	documents map[string]Document
+	postings  map[string]map[string]bool
+	sorted    []string
+	loaded    bool
+	saveTimer *time.Timer
+}
+
+func NewEmbeddedIndex(path string) (*EmbeddedIndex, error) {
+	index := &EmbeddedIndex{path: path}
+	index.reset(nil)
+
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return index, nil
+	} else if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var documents []Document
+	if err := gob.NewDecoder(file).Decode(&documents); err != nil {
+		return nil, err
+	}
+	index.reset(documents)
+	index.loaded = true
+	return index, nil
+}
+
+func (index *EmbeddedIndex) Documents(ctx context.Context) ([]Document, bool, error) {
+	index.mu.RLock()
+	defer index.mu.RUnlock()
+	if !index.loaded {
+		return nil, false, nil
+	}
+	return index.list(), true, nil
+}
+
+func (index *EmbeddedIndex) Upsert(ctx context.Context, document Document) error {
+	index.mu.Lock()
+	defer index.mu.Unlock()
+	index.remove(document.ID)
+	index.add(document)
+	index.scheduleSave()
+	return nil
+}
+
+func (index *EmbeddedIndex) Remove(ctx context.Context, id string) error {
+	index.mu.Lock()
+	defer index.mu.Unlock()
+	if _, ok := index.documents[id]; !ok {
+		return nil
+	}
+	index.remove(id)
+	index.scheduleSave()
+	return nil
+}
+
+func (index *EmbeddedIndex) Rebuild(ctx context.Context, documents []Document) error {
+	index.mu.Lock()
+	defer index.mu.Unlock()
+	index.reset(documents)
+	index.loaded = true
+	if index.saveTimer != nil {
+		index.saveTimer.Stop()
+		index.saveTimer = nil
+	}
+	return index.save()
+}
+
+func (index *EmbeddedIndex) Search(ctx context.Context, query Query) ([]string, error) {
+	index.mu.RLock()
+	defer index.mu.RUnlock()
+
+	var matches map[string]bool
+	for _, spellings := range query.Words {
+		found := make(map[string]bool)
+		for _, spelling := range spellings {
+			spelling = strings.ToLower(spelling)
+			start := sort.SearchStrings(index.sorted, spelling)
+			for i := start; i < len(index.sorted) && strings.HasPrefix(index.sorted[i], spelling); i++ {
+				for id := range index.postings[index.sorted[i]] {
+					if matches == nil || matches[id] {
+						found[id] = true
+					}
+				}
+			}
+		}
+		matches = found
+		if len(matches) == 0 {
+			break
+		}
+	}
+
+	ids := make([]string, 0, len(matches))
+	for id := range matches {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids, nil
+}
+
+func (index *EmbeddedIndex) reset(documents []Document) {
+	index.documents = make(map[string]Document, len(documents))
+	index.postings = make(map[string]map[string]bool)
+	index.sorted = make([]string, 0)
+	for _, document := range documents {
+		index.add(document)
+	}
+}
+
+func (index *EmbeddedIndex) add(document Document) {
+	index.documents[document.ID] = document
+	for _, word := range documentWords(document) {
+		ids, ok := index.postings[word]
+		if !ok {
+			ids = make(map[string]bool)
+			index.postings[word] = ids
+			i := sort.SearchStrings(index.sorted, word)
+			index.sorted = append(index.sorted, "")
+			copy(index.sorted[i+1:], index.sorted[i:])
+			index.sorted[i] = word
+		}
+		ids[document.ID] = true
+	}
+}
+
+func (index *EmbeddedIndex) remove(id string) {
+	document, ok := index.documents[id]
+	if !ok {
+		return
+	}
+	delete(index.documents, id)
+	for _, word := range documentWords(document) {
+		ids := index.postings[word]
+		delete(ids, id)
+		if len(ids) > 0 {
+			continue
+		}
+		delete(index.postings, word)
+		i := sort.SearchStrings(index.sorted, word)
+		if i < len(index.sorted) && index.sorted[i] == word {
+			index.sorted = append(index.sorted[:i], index.sorted[i+1:]...)
+		}
+	}
+}
+
+func (index *EmbeddedIndex) list() []Document {
+	documents := make([]Document, 0, len(index.documents))
+	for _, document := range index.documents {
+		documents = append(documents, document)
+	}
+	sort.Slice(documents, func(i, j int) bool {
+		return documents[i].ID < documents[j].ID
+	})
+	return documents
+}
+
+// scheduleSave saves the index after saveDelay unless a save is already pending.
+// It is called with the lock held.
+func (index *EmbeddedIndex) scheduleSave() {
+	if index.saveTimer != nil {
+		return
+	}
+	index.saveTimer = time.AfterFunc(saveDelay, func() {
+		index.mu.Lock()
+		defer index.mu.Unlock()
+		index.saveTimer = nil
+		if err := index.save(); err != nil {
+			log.Error().Err(err).Str("path", index.path).Msg("Error saving search index")
+		}
+	})
+}
+
+// save writes the documents to a temporary file and renames it over the index file,
+// so a crash never leaves a partial index behind.
+func (index *EmbeddedIndex) save() error {
+	if dir := filepath.Dir(index.path); dir != "" {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return err
+		}
+	}
+	file, err := os.CreateTemp(filepath.Dir(index.path), filepath.Base(index.path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+
+	if err := gob.NewEncoder(file).Encode(index.list()); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	return os.Rename(file.Name(), index.path)
+}
+
+// documentWords returns the distinct words of a document's name and ingredients.
+func documentWords(document Document) []string {
+	seen := make(map[string]bool)
+	words := make([]string, 0)
+	for _, text := range append([]string{document.Name}, document.Ingredients...) {
+		for _, word := range Words(text) {
+			if !seen[word] {
+				seen[word] = true
+				words = append(words, word)
+			}
+		}
+	}
+	return words
+}
@@ -0,0 +1,98 @@
+package search
+
+import (
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	// MaxFuzzyWords is the number of query words expanded or corrected; the rest are
+	// only matched exactly.
+	MaxFuzzyWords = 8
+	// maxFuzzyLength is the length beyond which words are only matched exactly, since
+	// comparing them with every indexed word gets expensive.
+	maxFuzzyLength = 30
+)
+
+// Expand returns the indexed name and ingredient words within MaxTypos of word,
+// closest first, always starting with word itself.
+func (suggester *Suggester) Expand(word string) []string {
+	word = strings.ToLower(word)
+	expanded := []string{word}
+	length := utf8.RuneCountInString(word)
+	if length > maxFuzzyLength {
+		return expanded
+	}
+
+	suggester.mu.RLock()
+	defer suggester.mu.RUnlock()
+
+	maxTypos := MaxTypos(word)
+	distances := make(map[string]int)
+	for _, candidate := range suggester.sorted {
+		if candidate == word || !withinLength(candidate, length, maxTypos) || !suggester.isTextWord(candidate) {
+			continue
+		}
+		if distance := Distance(word, candidate); distance <= maxTypos {
+			distances[candidate] = distance
+			expanded = append(expanded, candidate)
+		}
+	}
+	sort.SliceStable(expanded[1:], func(i, j int) bool {
+		return distances[expanded[i+1]] < distances[expanded[j+1]]
+	})
+	return expanded
+}
+
+// Correct replaces each word of query that is not an indexed name or ingredient word
+// with the closest one, preferring words used by more terms. Only the first
+// MaxFuzzyWords words are corrected. It reports false when nothing was corrected.
+func (suggester *Suggester) Correct(query string) (string, bool) {
+	suggester.mu.RLock()
+	defer suggester.mu.RUnlock()
+
+	words := Words(query)
+	corrected := false
+	for i, word := range words[:min(len(words), MaxFuzzyWords)] {
+		length := utf8.RuneCountInString(word)
+		if length > maxFuzzyLength || suggester.isTextWord(word) {
+			continue
+		}
+		maxTypos := MaxTypos(word)
+		best, bestDistance, bestUses := "", maxTypos+1, 0
+		for _, candidate := range suggester.sorted {
+			if !withinLength(candidate, length, maxTypos) || !suggester.isTextWord(candidate) {
+				continue
+			}
+			distance := Distance(word, candidate)
+			uses := len(suggester.words[candidate])
+			if distance < bestDistance || (distance == bestDistance && uses > bestUses) {
+				best, bestDistance, bestUses = candidate, distance, uses
+			}
+		}
+		if best != "" {
+			words[i] = best
+			corrected = true
+		}
+	}
+	return strings.Join(words, " "), corrected
+}
+
+// withinLength reports whether candidate's length is close enough to length for it to
+// be within maxTypos edits, which every edit changes by at most one.
+func withinLength(candidate string, length, maxTypos int) bool {
+	difference := utf8.RuneCountInString(candidate) - length
+	return difference >= -maxTypos && difference <= maxTypos
+}
+
+// isTextWord reports whether word appears in a recipe name or ingredient, as opposed
+// to only in tags.
+func (suggester *Suggester) isTextWord(word string) bool {
+	for key := range suggester.words[word] {
+		if key.kind != KindTag {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,109 @@
+package search
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDistance(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"garlic", "garlic", 0},
+		{"garlic", "garlik", 1},
+		{"garlic", "gralic", 1},
+		{"garlic", "garli", 1},
+		{"chiken", "chicken", 1},
+		{"crème", "creme", 1},
+		{"", "salt", 4},
+	}
+	for _, test := range tests {
+		if got := Distance(test.a, test.b); got != test.want {
+			t.Errorf("Distance(%q, %q) = %d, want %d", test.a, test.b, got, test.want)
+		}
+	}
+}
+
+func TestWithinLength(t *testing.T) {
+	tests := []struct {
+		candidate string
+		length    int
+		maxTypos  int
+		want      bool
+	}{
+		{"garlic", 6, 1, true},
+		{"garlics", 6, 1, true},
+		{"garlicky", 6, 1, false},
+		{"gar", 6, 2, false},
+		{"crème", 5, 0, true},
+	}
+	for _, test := range tests {
+		if got := withinLength(test.candidate, test.length, test.maxTypos); got != test.want {
+			t.Errorf("withinLength(%q, %d, %d) = %v, want %v", test.candidate, test.length, test.maxTypos, got, test.want)
+		}
+	}
+}
+
+func TestMaxTypos(t *testing.T) {
+	for word, want := range map[string]int{"ox": 0, "egg": 1, "garlic": 1, "chicken": 2} {
+		if got := MaxTypos(word); got != want {
+			t.Errorf("MaxTypos(%q) = %d, want %d", word, got, want)
+		}
+	}
+}
+
+func TestWords(t *testing.T) {
+	if got, want := Words("Chicken & Garlic, 30-min!"), []string{"chicken", "garlic", "30", "min"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Words() = %q, want %q", got, want)
+	}
+}
+
+func newTestSuggester() *Suggester {
+	suggester := NewSuggester()
+	suggester.Reset([]Document{
+		{ID: "1", Name: "Garlic Chicken", Ingredients: []string{"garlic", "chicken thighs"}},
+		{ID: "2", Name: "Chicken Soup", Tags: []string{"garlicky"}, Ingredients: []string{"chicken", "carrots"}},
+	})
+	return suggester
+}
+
+func TestExpand(t *testing.T) {
+	suggester := newTestSuggester()
+	tests := []struct {
+		word string
+		want []string
+	}{
+		{"garlik", []string{"garlik", "garlic"}},
+		{"chiken", []string{"chiken", "chicken"}},
+		{"soup", []string{"soup"}},
+		{"garlicy", []string{"garlicy", "garlic"}},
+		{strings.Repeat("a", maxFuzzyLength+1), []string{strings.Repeat("a", maxFuzzyLength+1)}},
+	}
+	for _, test := range tests {
+		if got := suggester.Expand(test.word); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("Expand(%q) = %q, want %q", test.word, got, test.want)
+		}
+	}
+}
+
+func TestCorrect(t *testing.T) {
+	suggester := newTestSuggester()
+	tests := []struct {
+		query     string
+		want      string
+		corrected bool
+	}{
+		{"garlik chiken", "garlic chicken", true},
+		{"chicken soup", "chicken soup", false},
+		{"zzzz", "zzzz", false},
+		{strings.Repeat("soup ", MaxFuzzyWords) + "garlik", strings.TrimSpace(strings.Repeat("soup ", MaxFuzzyWords)) + " garlik", false},
+	}
+	for _, test := range tests {
+		got, corrected := suggester.Correct(test.query)
+		if got != test.want || corrected != test.corrected {
+			t.Errorf("Correct(%q) = %q, %v, want %q, %v", test.query, got, corrected, test.want, test.corrected)
+		}
+	}
+}
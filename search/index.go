@@ -0,0 +1,39 @@
+package search
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mahesh-yadav/go-recipes-api/config"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+// Query matches documents containing every word, each as the start of a word in the
+// name or an ingredient. Words holds the accepted spellings of each query word.
+type Query struct {
+	Words [][]string
+}
+
+// SearchIndex finds recipes by text. Writes are applied as recipes change; Rebuild
+// replaces the whole index from the database.
+type SearchIndex interface {
+	// Documents returns the indexed documents, or false when the index keeps none and
+	// has to be rebuilt from the database.
+	Documents(ctx context.Context) ([]Document, bool, error)
+	Upsert(ctx context.Context, document Document) error
+	Remove(ctx context.Context, id string) error
+	Rebuild(ctx context.Context, documents []Document) error
+	// Search returns the IDs of the matching documents.
+	Search(ctx context.Context, query Query) ([]string, error)
+}
+
+func NewSearchIndex(config *config.Config, collection *mongo.Collection) (SearchIndex, error) {
+	switch config.SearchBackend {
+	case "mongo":
+		return NewMongoIndex(collection), nil
+	case "embedded":
+		return NewEmbeddedIndex(config.SearchIndexPath)
+	default:
+		return nil, fmt.Errorf("unknown search backend: %s", config.SearchBackend)
+	}
+}
@@ -0,0 +1,74 @@
+package search
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// MongoIndex searches the recipes collection directly with regular expressions, so
+// it has nothing to maintain.
+type MongoIndex struct {
+	collection *mongo.Collection
+}
+
+func NewMongoIndex(collection *mongo.Collection) *MongoIndex {
+	return &MongoIndex{collection: collection}
+}
+
+func (index *MongoIndex) Documents(ctx context.Context) ([]Document, bool, error) {
+	return nil, false, nil
+}
+
+func (index *MongoIndex) Upsert(ctx context.Context, document Document) error {
+	return nil
+}
+
+func (index *MongoIndex) Remove(ctx context.Context, id string) error {
+	return nil
+}
+
+func (index *MongoIndex) Rebuild(ctx context.Context, documents []Document) error {
+	return nil
+}
+
+func (index *MongoIndex) Search(ctx context.Context, query Query) ([]string, error) {
+	opts := options.Find().SetProjection(bson.D{{Key: "_id", Value: 1}})
+	cursor, err := index.collection.Find(ctx, TextFilter(query), opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []struct {
+		ID bson.ObjectID `bson:"_id"`
+	}
+	if err := cursor.All(ctx, &results); err != nil {
+		return nil, err
+	}
+	ids := make([]string, 0, len(results))
+	for _, result := range results {
+		ids = append(ids, result.ID.Hex())
+	}
+	return ids, nil
+}
+
+// TextFilter requires every query word to start a word of the name or an ingredient.
+func TextFilter(query Query) bson.D {
+	clauses := make(bson.A, 0, len(query.Words))
+	for _, spellings := range query.Words {
+		quoted := make([]string, 0, len(spellings))
+		for _, spelling := range spellings {
+			quoted = append(quoted, regexp.QuoteMeta(spelling))
+		}
+		pattern := bson.Regex{Pattern: `\b(` + strings.Join(quoted, "|") + `)`, Options: "i"}
+		clauses = append(clauses, bson.D{{Key: "$or", Value: bson.A{
+			bson.D{{Key: "name", Value: pattern}},
+			bson.D{{Key: "ingredients", Value: pattern}},
+		}}})
+	}
+	return bson.D{{Key: "$and", Value: clauses}}
+}
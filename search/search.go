@@ -0,0 +1,38 @@
+// Package search indexes recipe text for suggestions and typo-tolerant matching.
+package search
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/mahesh-yadav/go-recipes-api/ingredients"
+)
+
+// Document is the searchable text of a recipe.
+type Document struct {
+	ID          string
+	Name        string
+	Tags        []string
+	Ingredients []string
+}
+
+// Words splits text into lowercase words of letters and digits.
+func Words(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// IngredientItems returns the normalized ingredient names of a recipe, without duplicates.
+func IngredientItems(lines []string) []string {
+	seen := make(map[string]bool, len(lines))
+	items := make([]string, 0, len(lines))
+	for _, line := range lines {
+		item := ingredients.Normalize(ingredients.Parse(line).Name)
+		if item != "" && !seen[item] {
+			seen[item] = true
+			items = append(items, item)
+		}
+	}
+	return items
+}
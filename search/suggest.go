@@ -0,0 +1,259 @@
+package search
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"sync"
+)
+
+const (
+	KindName       = "name"
+	KindTag        = "tag"
+	KindIngredient = "ingredient"
+)
+
+type Suggestion struct {
+	Text  string
+	Kind  string
+	Count int
+	Score float64
+}
+
+type termKey struct {
+	kind string
+	text string
+}
+
+type term struct {
+	key     termKey
+	display string
+	words   []string
+	recipes map[string]bool
+}
+
+// Suggester is an in-memory index of recipe names, tags and ingredient items for
+// autocomplete. It is safe for concurrent use and is updated one recipe at a time.
+type Suggester struct {
+	mu        sync.RWMutex
+	terms     map[termKey]*term
+	words     map[string]map[termKey]bool
+	sorted    []string
+	documents map[string][]termKey
+}
+
+func NewSuggester() *Suggester {
+	return &Suggester{
+		terms:     make(map[termKey]*term),
+		words:     make(map[string]map[termKey]bool),
+		sorted:    make([]string, 0),
+		documents: make(map[string][]termKey),
+	}
+}
+
+// Reset replaces the whole index with the given documents.
+func (suggester *Suggester) Reset(documents []Document) {
+	fresh := NewSuggester()
+	for _, document := range documents {
+		fresh.add(document)
+	}
+
+	suggester.mu.Lock()
+	defer suggester.mu.Unlock()
+	suggester.terms = fresh.terms
+	suggester.words = fresh.words
+	suggester.sorted = fresh.sorted
+	suggester.documents = fresh.documents
+}
+
+// Upsert indexes a document, replacing its previous version.
+func (suggester *Suggester) Upsert(document Document) {
+	suggester.mu.Lock()
+	defer suggester.mu.Unlock()
+	suggester.remove(document.ID)
+	suggester.add(document)
+}
+
+// Remove drops a document from the index.
+func (suggester *Suggester) Remove(id string) {
+	suggester.mu.Lock()
+	defer suggester.mu.Unlock()
+	suggester.remove(id)
+}
+
+// Suggest returns up to limit terms completing query. Every word of the query must
+// match a word of the term, the last one as a prefix, each within MaxTypos typos.
+func (suggester *Suggester) Suggest(query string, limit int) []Suggestion {
+	queryWords := Words(query)
+	if len(queryWords) == 0 || limit <= 0 {
+		return []Suggestion{}
+	}
+
+	suggester.mu.RLock()
+	defer suggester.mu.RUnlock()
+
+	last := queryWords[len(queryWords)-1]
+	typos := make(map[termKey]int)
+	for word, distance := range suggester.matchingWords(last) {
+		for key := range suggester.words[word] {
+			if current, ok := typos[key]; !ok || distance < current {
+				typos[key] = distance
+			}
+		}
+	}
+
+	normalized := strings.Join(queryWords, " ")
+	suggestions := make([]Suggestion, 0)
+	for key, distance := range typos {
+		term := suggester.terms[key]
+		total, ok := matchOthers(queryWords[:len(queryWords)-1], term.words)
+		if !ok {
+			continue
+		}
+		distance += total
+
+		// Prefer popular, short terms that start with the query.
+		score := 1 - 0.4*float64(distance) + 0.1*math.Log1p(float64(len(term.recipes)))
+		score -= 0.05 * float64(len(term.words)-len(queryWords))
+		if strings.HasPrefix(key.text, normalized) {
+			score += 0.5
+		} else if first := PrefixDistance(queryWords[0], term.words[0]); first <= MaxTypos(queryWords[0]) {
+			score += 0.5 - 0.25*float64(first)
+		}
+		suggestions = append(suggestions, Suggestion{
+			Text:  term.display,
+			Kind:  term.key.kind,
+			Count: len(term.recipes),
+			Score: score,
+		})
+	}
+
+	sort.Slice(suggestions, func(i, j int) bool {
+		if suggestions[i].Score != suggestions[j].Score {
+			return suggestions[i].Score > suggestions[j].Score
+		}
+		return suggestions[i].Text < suggestions[j].Text
+	})
+	if len(suggestions) > limit {
+		suggestions = suggestions[:limit]
+	}
+	return suggestions
+}
+
+// matchingWords returns the indexed words starting with query, with their typo count.
+// Exact prefixes are found by binary search; the fuzzy scan over all words only runs
+// for queries long enough to tolerate typos.
+func (suggester *Suggester) matchingWords(query string) map[string]int {
+	matches := make(map[string]int)
+	start := sort.SearchStrings(suggester.sorted, query)
+	for i := start; i < len(suggester.sorted) && strings.HasPrefix(suggester.sorted[i], query); i++ {
+		matches[suggester.sorted[i]] = 0
+	}
+
+	maxTypos := MaxTypos(query)
+	if maxTypos == 0 {
+		return matches
+	}
+	for _, word := range suggester.sorted {
+		if _, ok := matches[word]; ok {
+			continue
+		}
+		if distance := PrefixDistance(query, word); distance <= maxTypos {
+			matches[word] = distance
+		}
+	}
+	return matches
+}
+
+// matchOthers checks that every query word matches one of the term's words and
+// returns the typos needed.
+func matchOthers(queryWords, termWords []string) (int, bool) {
+	total := 0
+	for _, queryWord := range queryWords {
+		best := -1
+		for _, word := range termWords {
+			distance := PrefixDistance(queryWord, word)
+			if distance <= MaxTypos(queryWord) && (best < 0 || distance < best) {
+				best = distance
+			}
+		}
+		if best < 0 {
+			return 0, false
+		}
+		total += best
+	}
+	return total, true
+}
+
+func (suggester *Suggester) add(document Document) {
+	keys := make([]termKey, 0)
+	addTerm := func(kind, display string) {
+		words := Words(display)
+		if len(words) == 0 {
+			return
+		}
+		key := termKey{kind: kind, text: strings.Join(words, " ")}
+		existing, ok := suggester.terms[key]
+		if !ok {
+			existing = &term{key: key, display: display, words: words, recipes: make(map[string]bool)}
+			suggester.terms[key] = existing
+			for _, word := range words {
+				suggester.addWord(word, key)
+			}
+		}
+		if !existing.recipes[document.ID] {
+			existing.recipes[document.ID] = true
+			keys = append(keys, key)
+		}
+	}
+
+	addTerm(KindName, strings.TrimSpace(document.Name))
+	for _, tag := range document.Tags {
+		addTerm(KindTag, tag)
+	}
+	for _, item := range IngredientItems(document.Ingredients) {
+		addTerm(KindIngredient, item)
+	}
+	suggester.documents[document.ID] = keys
+}
+
+func (suggester *Suggester) remove(id string) {
+	for _, key := range suggester.documents[id] {
+		term := suggester.terms[key]
+		delete(term.recipes, id)
+		if len(term.recipes) > 0 {
+			continue
+		}
+		delete(suggester.terms, key)
+		for _, word := range term.words {
+			suggester.removeWord(word, key)
+		}
+	}
+	delete(suggester.documents, id)
+}
+
+func (suggester *Suggester) addWord(word string, key termKey) {
+	keys, ok := suggester.words[word]
+	if !ok {
+		keys = make(map[termKey]bool)
+		suggester.words[word] = keys
+		i := sort.SearchStrings(suggester.sorted, word)
+		suggester.sorted = append(suggester.sorted, "")
+		copy(suggester.sorted[i+1:], suggester.sorted[i:])
+		suggester.sorted[i] = word
+	}
+	keys[key] = true
+}
+
+func (suggester *Suggester) removeWord(word string, key termKey) {
+	keys := suggester.words[word]
+	delete(keys, key)
+	if len(keys) > 0 {
+		return
+	}
+	delete(suggester.words, word)
+	i := sort.SearchStrings(suggester.sorted, word)
+	if i < len(suggester.sorted) && suggester.sorted[i] == word {
+		suggester.sorted = append(suggester.sorted[:i], suggester.sorted[i+1:]...)
+	}
+}
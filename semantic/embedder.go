@@ -0,0 +1,149 @@
+// Package semantic embeds recipe text as vectors and finds the recipes closest to a
+// query by cosine similarity.
+package semantic
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"strings"
+	"unicode"
+
+	"github.com/mahesh-yadav/go-recipes-api/config"
+	"github.com/mahesh-yadav/go-recipes-api/ingredients"
+)
+
+// concepts.json groups words evoking the same idea, so that "cozy winter soup" lands
+// near stews and chowders even though neither word appears in them.
+//
+//go:embed concepts.json
+var conceptsJSON []byte
+
+var concepts = loadConcepts()
+
+// featureWeights weights features by kind, keyed by the first letter of their prefix.
+var featureWeights = map[byte]float64{
+	'w': 1.0,
+	'c': 0.6,
+	't': 0.25,
+}
+
+var stopwords = map[string]bool{
+	"a": true, "an": true, "and": true, "or": true, "of": true, "the": true, "to": true, "for": true,
+	"with": true, "in": true, "on": true, "my": true, "some": true, "something": true, "recipe": true,
+}
+
+// Embedder turns text into a vector. Vectors of different models are not comparable,
+// so Model changes whenever the output would.
+type Embedder interface {
+	Model() string
+	Dimensions() int
+	Embed(text string) []float32
+}
+
+func NewEmbedder(config *config.Config) (Embedder, error) {
+	switch config.Embedder {
+	case "hashing":
+		if config.EmbeddingDimensions <= 0 {
+			return nil, fmt.Errorf("embedding dimensions must be positive, got %d", config.EmbeddingDimensions)
+		}
+		return NewHashingEmbedder(config.EmbeddingDimensions), nil
+	default:
+		return nil, fmt.Errorf("unknown embedder: %s", config.Embedder)
+	}
+}
+
+// HashingEmbedder hashes words, their letter trigrams and their concepts into a fixed
+// number of dimensions, weighting repeated features sublinearly. It needs no training,
+// so the same text always gets the same vector.
+type HashingEmbedder struct {
+	dimensions int
+}
+
+func NewHashingEmbedder(dimensions int) *HashingEmbedder {
+	return &HashingEmbedder{dimensions: dimensions}
+}
+
+func (embedder *HashingEmbedder) Model() string {
+	return fmt.Sprintf("hashing-v2-%d", embedder.dimensions)
+}
+
+func (embedder *HashingEmbedder) Dimensions() int {
+	return embedder.dimensions
+}
+
+func (embedder *HashingEmbedder) Embed(text string) []float32 {
+	counts := make(map[string]int)
+	for _, word := range words(text) {
+		counts["w:"+word]++
+		padded := " " + word + " "
+		for i := 0; i+3 <= len(padded); i++ {
+			counts["t:"+padded[i:i+3]]++
+		}
+		for _, concept := range concepts[word] {
+			counts["c:"+concept]++
+		}
+	}
+
+	vector := make([]float64, embedder.dimensions)
+	for feature, count := range counts {
+		h := fnv.New64a()
+		h.Write([]byte(feature))
+		sum := h.Sum64()
+		sign := 1.0
+		if sum>>63 == 1 {
+			sign = -1
+		}
+		vector[sum%uint64(embedder.dimensions)] += sign * featureWeights[feature[0]] * (1 + math.Log(float64(count)))
+	}
+	return normalize(vector)
+}
+
+// words returns the singular, lowercase words of text without stopwords.
+func words(text string) []string {
+	fields := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r)
+	})
+	words := make([]string, 0, len(fields))
+	for _, field := range fields {
+		if !stopwords[field] {
+			words = append(words, ingredients.Singular(field))
+		}
+	}
+	return words
+}
+
+func normalize(vector []float64) []float32 {
+	var norm float64
+	for _, value := range vector {
+		norm += value * value
+	}
+	norm = math.Sqrt(norm)
+
+	normalized := make([]float32, len(vector))
+	if norm == 0 {
+		return normalized
+	}
+	for i, value := range vector {
+		normalized[i] = float32(value / norm)
+	}
+	return normalized
+}
+
+// loadConcepts maps each word to the concepts it belongs to.
+func loadConcepts() map[string][]string {
+	var groups map[string][]string
+	if err := json.Unmarshal(conceptsJSON, &groups); err != nil {
+		panic("invalid embedded concepts: " + err.Error())
+	}
+	concepts := make(map[string][]string)
+	for concept, words := range groups {
+		for _, word := range words {
+			word = ingredients.Singular(word)
+			concepts[word] = append(concepts[word], concept)
+		}
+	}
+	return concepts
+}
@@ -0,0 +1,76 @@
+package semantic
+
+import (
+	"math"
+	"testing"
+
+	"github.com/mahesh-yadav/go-recipes-api/config"
+)
+
+func TestNewEmbedder(t *testing.T) {
+	tests := []struct {
+		name       string
+		embedder   string
+		dimensions int
+		valid      bool
+	}{
+		{name: "hashing", embedder: "hashing", dimensions: 256, valid: true},
+		{name: "zero dimensions", embedder: "hashing", dimensions: 0},
+		{name: "negative dimensions", embedder: "hashing", dimensions: -8},
+		{name: "unknown", embedder: "openai", dimensions: 256},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			embedder, err := NewEmbedder(&config.Config{Embedder: test.embedder, EmbeddingDimensions: test.dimensions})
+			if test.valid != (err == nil) {
+				t.Fatalf("error = %v, want valid %v", err, test.valid)
+			}
+			if test.valid && embedder.Dimensions() != test.dimensions {
+				t.Errorf("dimensions = %d, want %d", embedder.Dimensions(), test.dimensions)
+			}
+		})
+	}
+}
+
+func TestHashingEmbedder(t *testing.T) {
+	embedder := NewHashingEmbedder(256)
+
+	for _, text := range []string{"tomato soup", "Soup soup soup, TOMATOES!", "chicken"} {
+		vector := embedder.Embed(text)
+		if len(vector) != 256 {
+			t.Fatalf("Embed(%q) has %d dimensions, want 256", text, len(vector))
+		}
+		if norm := math.Sqrt(dot(vector, vector)); math.Abs(norm-1) > 1e-6 {
+			t.Errorf("Embed(%q) has norm %f, want 1", text, norm)
+		}
+	}
+
+	for _, text := range []string{"", "the and of", "123 !!"} {
+		if norm := dot(embedder.Embed(text), embedder.Embed(text)); norm != 0 {
+			t.Errorf("Embed(%q) has norm %f, want 0", text, norm)
+		}
+	}
+
+	// Plurals, case and stopwords do not change the vector.
+	if score := dot(embedder.Embed("tomato soup"), embedder.Embed("The Tomatoes Soups")); math.Abs(score-1) > 1e-6 {
+		t.Errorf("similarity of equivalent texts = %f, want 1", score)
+	}
+
+	// Repeating a word gives it more weight, never less.
+	query := embedder.Embed("soup")
+	once := dot(query, embedder.Embed("soup with bread"))
+	twice := dot(query, embedder.Embed("soup soup with bread"))
+	if twice <= once {
+		t.Errorf("similarity with a repeated word = %f, want more than %f", twice, once)
+	}
+}
+
+func TestHashingEmbedderConcepts(t *testing.T) {
+	embedder := NewHashingEmbedder(1024)
+	query := embedder.Embed("cozy winter soup")
+	related := dot(query, embedder.Embed("beef stew"))
+	unrelated := dot(query, embedder.Embed("lemon sorbet"))
+	if related <= unrelated {
+		t.Errorf("similarity to stew = %f, want more than sorbet at %f", related, unrelated)
+	}
+}
@@ -0,0 +1,174 @@
+package semantic
+
+import (
+	"math/rand"
+	"sort"
+	"sync"
+)
+
+const (
+	lshTables = 8
+	lshBits   = 12
+	// exactSearchSize is the number of vectors up to which every vector is scored,
+	// which is both exact and fast enough.
+	exactSearchSize = 2000
+)
+
+type Scored struct {
+	ID    string
+	Score float64
+}
+
+// Index finds the nearest vectors by cosine similarity. Large indexes use random
+// hyperplane hashing: vectors pointing the same way tend to fall on the same side
+// of each plane, so only vectors sharing a bucket with the query, or a bucket one
+// plane away, are scored.
+type Index struct {
+	mu      sync.RWMutex
+	planes  [lshTables][lshBits][]float32
+	buckets [lshTables]map[uint64]map[string]bool
+	vectors map[string][]float32
+}
+
+func NewIndex(dimensions int) *Index {
+	index := &Index{}
+	random := rand.New(rand.NewSource(1))
+	for table := range index.planes {
+		for bit := range index.planes[table] {
+			plane := make([]float32, dimensions)
+			for i := range plane {
+				plane[i] = float32(random.NormFloat64())
+			}
+			index.planes[table][bit] = plane
+		}
+	}
+	index.Reset(nil)
+	return index
+}
+
+// Reset replaces the indexed vectors, which must be normalized.
+func (index *Index) Reset(vectors map[string][]float32) {
+	index.mu.Lock()
+	defer index.mu.Unlock()
+	index.vectors = make(map[string][]float32, len(vectors))
+	for table := range index.buckets {
+		index.buckets[table] = make(map[uint64]map[string]bool)
+	}
+	for id, vector := range vectors {
+		index.add(id, vector)
+	}
+}
+
+func (index *Index) Upsert(id string, vector []float32) {
+	index.mu.Lock()
+	defer index.mu.Unlock()
+	index.remove(id)
+	index.add(id, vector)
+}
+
+func (index *Index) Remove(id string) {
+	index.mu.Lock()
+	defer index.mu.Unlock()
+	index.remove(id)
+}
+
+func (index *Index) Len() int {
+	index.mu.RLock()
+	defer index.mu.RUnlock()
+	return len(index.vectors)
+}
+
+// Search returns up to limit vectors most similar to the query, best first, leaving
+// out those with no similarity at all.
+func (index *Index) Search(query []float32, limit int) []Scored {
+	index.mu.RLock()
+	defer index.mu.RUnlock()
+
+	candidates := index.vectors
+	if len(index.vectors) > exactSearchSize {
+		candidates = index.candidates(query)
+	}
+
+	results := make([]Scored, 0)
+	for id, vector := range candidates {
+		if score := dot(query, vector); score > 0 {
+			results = append(results, Scored{ID: id, Score: score})
+		}
+	}
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		return results[i].ID < results[j].ID
+	})
+	if len(results) > limit {
+		results = results[:limit]
+	}
+	return results
+}
+
+func (index *Index) candidates(query []float32) map[string][]float32 {
+	candidates := make(map[string][]float32)
+	for table := range index.buckets {
+		key := index.hash(table, query)
+		for bit := -1; bit < lshBits; bit++ {
+			probe := key
+			if bit >= 0 {
+				probe ^= 1 << bit
+			}
+			for id := range index.buckets[table][probe] {
+				candidates[id] = index.vectors[id]
+			}
+		}
+	}
+	return candidates
+}
+
+func (index *Index) add(id string, vector []float32) {
+	index.vectors[id] = vector
+	for table := range index.buckets {
+		key := index.hash(table, vector)
+		bucket, ok := index.buckets[table][key]
+		if !ok {
+			bucket = make(map[string]bool)
+			index.buckets[table][key] = bucket
+		}
+		bucket[id] = true
+	}
+}
+
+func (index *Index) remove(id string) {
+	vector, ok := index.vectors[id]
+	if !ok {
+		return
+	}
+	delete(index.vectors, id)
+	for table := range index.buckets {
+		key := index.hash(table, vector)
+		delete(index.buckets[table][key], id)
+		if len(index.buckets[table][key]) == 0 {
+			delete(index.buckets[table], key)
+		}
+	}
+}
+
+// hash records on which side of each of the table's planes the vector lies.
+func (index *Index) hash(table int, vector []float32) uint64 {
+	var key uint64
+	for bit, plane := range index.planes[table] {
+		if dot(plane, vector) >= 0 {
+			key |= 1 << bit
+		}
+	}
+	return key
+}
+
+func dot(a, b []float32) float64 {
+	var sum float64
+	for i := range a {
+		if i < len(b) {
+			sum += float64(a[i]) * float64(b[i])
+		}
+	}
+	return sum
+}
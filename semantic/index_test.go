@@ -0,0 +1,63 @@
+package semantic
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestIndexSearch(t *testing.T) {
+	embedder := NewHashingEmbedder(256)
+	index := NewIndex(embedder.Dimensions())
+	index.Reset(map[string][]float32{
+		"soup":  embedder.Embed("tomato soup"),
+		"stew":  embedder.Embed("tomato beef stew"),
+		"salad": embedder.Embed("green salad"),
+	})
+
+	results := index.Search(embedder.Embed("tomato soup"), 10)
+	ids := make([]string, 0, len(results))
+	for _, result := range results {
+		ids = append(ids, result.ID)
+	}
+	if len(ids) < 2 || ids[0] != "soup" || ids[1] != "stew" {
+		t.Errorf("results = %v, want soup then stew", ids)
+	}
+	for i := 1; i < len(results); i++ {
+		if results[i].Score > results[i-1].Score {
+			t.Errorf("results are not sorted by score: %+v", results)
+		}
+	}
+
+	if results := index.Search(embedder.Embed("tomato soup"), 1); len(results) != 1 {
+		t.Errorf("got %d results, want the limit of 1", len(results))
+	}
+
+	index.Remove("soup")
+	index.Upsert("stew", embedder.Embed("green salad"))
+	if index.Len() != 2 {
+		t.Errorf("len = %d, want 2", index.Len())
+	}
+	results = index.Search(embedder.Embed("tomato soup"), 10)
+	for _, result := range results {
+		if result.ID == "soup" {
+			t.Errorf("removed vector found: %+v", results)
+		}
+	}
+}
+
+func TestIndexSearchLarge(t *testing.T) {
+	embedder := NewHashingEmbedder(256)
+	index := NewIndex(embedder.Dimensions())
+	vectors := make(map[string][]float32)
+	for i := 0; i < exactSearchSize+500; i++ {
+		vectors[fmt.Sprint(i)] = embedder.Embed(fmt.Sprintf("recipe%c%c%c", 'a'+i%26, 'a'+i/26%26, 'a'+i/676%26))
+	}
+	index.Reset(vectors)
+
+	for _, id := range []string{"0", "1234", "2400"} {
+		results := index.Search(vectors[id], 1)
+		if len(results) != 1 || results[0].ID != id || results[0].Score < 0.999 {
+			t.Errorf("search for vector %s = %+v, want itself", id, results)
+		}
+	}
+}
@@ -0,0 +1,42 @@
+// Package sharing signs and verifies time-limited links to recipes.
+package sharing
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"time"
+)
+
+var (
+	ErrExpired          = errors.New("share link has expired")
+	ErrInvalidSignature = errors.New("share link signature is invalid")
+)
+
+// Sign returns the hex encoded HMAC-SHA256 of the recipe ID and expiry.
+func Sign(secret []byte, id string, expires time.Time) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(id))
+	mac.Write([]byte{':'})
+	mac.Write([]byte(strconv.FormatInt(expires.Unix(), 10)))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify checks that signature was produced by Sign for id and expires, and that
+// the link has not expired at now.
+func Verify(secret []byte, id string, expires time.Time, signature string, now time.Time) error {
+	expected, err := hex.DecodeString(Sign(secret, id, expires))
+	if err != nil {
+		return err
+	}
+	actual, err := hex.DecodeString(signature)
+	if err != nil || !hmac.Equal(expected, actual) {
+		return ErrInvalidSignature
+	}
+	if !now.Before(expires) {
+		return ErrExpired
+	}
+	return nil
+}
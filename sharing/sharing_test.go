@@ -0,0 +1,53 @@
+package sharing
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestVerify(t *testing.T) {
+	secret := []byte("secret")
+	id := "c0283p3d0cvuglq85log"
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	expires := now.Add(time.Hour)
+	signature := Sign(secret, id, expires)
+
+	tests := []struct {
+		name      string
+		secret    []byte
+		id        string
+		expires   time.Time
+		signature string
+		now       time.Time
+		err       error
+	}{
+		{name: "valid", secret: secret, id: id, expires: expires, signature: signature, now: now},
+		{name: "just before expiry", secret: secret, id: id, expires: expires, signature: signature, now: expires.Add(-time.Second)},
+		{name: "at expiry", secret: secret, id: id, expires: expires, signature: signature, now: expires, err: ErrExpired},
+		{name: "after expiry", secret: secret, id: id, expires: expires, signature: signature, now: expires.Add(time.Hour), err: ErrExpired},
+		{name: "other recipe", secret: secret, id: "c0283p3d0cvuglq85lol", expires: expires, signature: signature, now: now, err: ErrInvalidSignature},
+		{name: "extended expiry", secret: secret, id: id, expires: expires.Add(24 * time.Hour), signature: signature, now: now, err: ErrInvalidSignature},
+		{name: "other secret", secret: []byte("other"), id: id, expires: expires, signature: signature, now: now, err: ErrInvalidSignature},
+		{name: "tampered signature", secret: secret, id: id, expires: expires, signature: "00" + signature[2:], now: now, err: ErrInvalidSignature},
+		{name: "truncated signature", secret: secret, id: id, expires: expires, signature: signature[:32], now: now, err: ErrInvalidSignature},
+		{name: "not hex", secret: secret, id: id, expires: expires, signature: "not a signature", now: now, err: ErrInvalidSignature},
+		{name: "empty signature", secret: secret, id: id, expires: expires, now: now, err: ErrInvalidSignature},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := Verify(test.secret, test.id, test.expires, test.signature, test.now)
+			if !errors.Is(err, test.err) {
+				t.Errorf("Verify() error = %v, want %v", err, test.err)
+			}
+		})
+	}
+}
+
+func TestSignIgnoresSubsecondExpiry(t *testing.T) {
+	secret := []byte("secret")
+	expires := time.Date(2024, 5, 1, 13, 0, 0, 0, time.UTC)
+	if Sign(secret, "id", expires) != Sign(secret, "id", expires.Add(500*time.Millisecond)) {
+		t.Error("Sign() differs within the same second, links carry expiry in seconds")
+	}
+}
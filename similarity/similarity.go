@@ -0,0 +1,136 @@
+package similarity
+
+import (
+	"math"
+	"sort"
+
+	"github.com/mahesh-yadav/go-recipes-api/ingredients"
+)
+
+const (
+	ingredientWeight = 0.7
+	tagWeight        = 0.3
+)
+
+type Document struct {
+	ID          string
+	Ingredients []string
+	Tags        []string
+}
+
+type Scored struct {
+	ID    string
+	Score float64
+}
+
+// Model holds TF-IDF ingredient vectors and tag sets for a corpus of recipes.
+type Model struct {
+	ids     []string
+	vectors map[string]map[string]float64
+	tags    map[string]map[string]bool
+}
+
+func NewModel(documents []Document) *Model {
+	model := &Model{
+		ids:     make([]string, 0, len(documents)),
+		vectors: make(map[string]map[string]float64, len(documents)),
+		tags:    make(map[string]map[string]bool, len(documents)),
+	}
+
+	termFrequencies := make(map[string]map[string]float64, len(documents))
+	documentFrequency := make(map[string]int)
+	for _, document := range documents {
+		frequencies := make(map[string]float64)
+		for _, line := range document.Ingredients {
+			name := ingredients.Normalize(ingredients.Parse(line).Name)
+			if name != "" {
+				frequencies[name]++
+			}
+		}
+		for term := range frequencies {
+			documentFrequency[term]++
+		}
+		termFrequencies[document.ID] = frequencies
+
+		tags := make(map[string]bool)
+		for _, tag := range document.Tags {
+			tags[ingredients.Normalize(tag)] = true
+		}
+		model.tags[document.ID] = tags
+		model.ids = append(model.ids, document.ID)
+	}
+
+	total := float64(len(documents))
+	for id, frequencies := range termFrequencies {
+		vector := make(map[string]float64, len(frequencies))
+		var norm float64
+		for term, frequency := range frequencies {
+			weight := frequency * math.Log(1+total/float64(documentFrequency[term]))
+			vector[term] = weight
+			norm += weight * weight
+		}
+		norm = math.Sqrt(norm)
+		for term := range vector {
+			vector[term] /= norm
+		}
+		model.vectors[id] = vector
+	}
+
+	return model
+}
+
+// Similar returns up to limit documents most similar to the one with the given id,
+// best first. Documents sharing nothing with it are left out.
+func (m *Model) Similar(id string, limit int) []Scored {
+	target, ok := m.vectors[id]
+	if !ok {
+		return []Scored{}
+	}
+
+	results := make([]Scored, 0)
+	for _, other := range m.ids {
+		if other == id {
+			continue
+		}
+		score := ingredientWeight*cosine(target, m.vectors[other]) + tagWeight*jaccard(m.tags[id], m.tags[other])
+		if score > 0 {
+			results = append(results, Scored{ID: other, Score: score})
+		}
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		return results[i].ID < results[j].ID
+	})
+	if len(results) > limit {
+		results = results[:limit]
+	}
+	return results
+}
+
+// cosine expects both vectors to be normalized.
+func cosine(a, b map[string]float64) float64 {
+	if len(a) > len(b) {
+		a, b = b, a
+	}
+	var dot float64
+	for term, weight := range a {
+		dot += weight * b[term]
+	}
+	return dot
+}
+
+func jaccard(a, b map[string]bool) float64 {
+	if len(a) == 0 && len(b) == 0 {
+		return 0
+	}
+	intersection := 0
+	for tag := range a {
+		if b[tag] {
+			intersection++
+		}
+	}
+	return float64(intersection) / float64(len(a)+len(b)-intersection)
+}
@@ -0,0 +1,164 @@
+package steps
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/mahesh-yadav/go-recipes-api/models"
+)
+
+const (
+	maxSectionWords  = 7
+	maxSectionLength = 50
+)
+
+var (
+	paragraphBreakRegex = regexp.MustCompile(`\r?\n\s*\r?\n`)
+	markdownEmphasis    = regexp.MustCompile(`\*\*|__`)
+	// durationRegex matches an amount, or a range of amounts, followed by a time unit:
+	// "5 minutes", "about4 minutes", "4 to 5 minutes", "8-10 mins", "1 1/2 hours".
+	durationRegex = regexp.MustCompile(`(?i)(\d+(?:\.\d+)?(?:\s+1/2|\s*½)?)(?:\s*(?:-|–|to)\s*(\d+(?:\.\d+)?))?\s*(hours?|hrs?|minutes?|mins?|seconds?|secs?)\b`)
+	// joinerRegex matches what may sit between two durations that add up, as in
+	// "1 hour, 30 minutes", or form a range, as in "45 minutes to 1 hour".
+	joinerRegex = regexp.MustCompile(`^\s*(,|and|to|-|–)?\s*$`)
+	// headingRegex matches lead-ins that name a part of the recipe even when the step
+	// goes on after them, as in "To make the sauce: whisk...".
+	headingRegex = regexp.MustCompile(`(?i)^(to|for)\s`)
+)
+
+// asides are lead-ins that comment on a step rather than start a section.
+var asides = map[string]bool{
+	"note": true, "notes": true, "tip": true, "tips": true, "hint": true, "optional": true,
+	"important": true, "variation": true, "variations": true, "warning": true,
+}
+
+// Structure turns loosely written steps into structured ones. Paragraphs inside a step
+// become steps of their own, a "To cook the chicken:" style lead-in or a short heading
+// standing as its own paragraph starts a new section that applies to the following
+// steps, and steps without a timer get one when their text mentions a duration.
+func Structure(instructions models.Instructions) models.Instructions {
+	structured := make(models.Instructions, 0, len(instructions))
+	section := ""
+	for _, step := range instructions {
+		if step.Section != "" {
+			section = step.Section
+		}
+
+		// The step's own timer, image and ingredients go to the first step kept from it,
+		// which is not the first paragraph when that only names a section.
+		first := true
+		for _, paragraph := range paragraphBreakRegex.Split(step.Text, -1) {
+			paragraph = strings.TrimSpace(paragraph)
+			if header, rest, found := splitSectionHeader(paragraph); found {
+				section = header
+				paragraph = rest
+			}
+			if !hasLetters(paragraph) {
+				continue
+			}
+
+			structuredStep := models.Step{Section: section, Text: paragraph}
+			if first {
+				structuredStep.TimerSeconds = step.TimerSeconds
+				structuredStep.ImageID = step.ImageID
+				structuredStep.Ingredients = step.Ingredients
+				first = false
+			}
+			if structuredStep.TimerSeconds == 0 {
+				structuredStep.TimerSeconds = int(DetectTimer(paragraph).Seconds())
+			}
+			structured = append(structured, structuredStep)
+		}
+	}
+	return structured
+}
+
+// DetectTimer returns the first duration mentioned in a step, using the upper bound of
+// ranges such as "about 4 to 5 minutes", or zero when there is none.
+func DetectTimer(text string) time.Duration {
+	matches := durationRegex.FindAllStringSubmatchIndex(text, -1)
+	if len(matches) == 0 {
+		return 0
+	}
+
+	total := durationAt(text, matches[0])
+	for i := 1; i < len(matches); i++ {
+		joiner := joinerRegex.FindStringSubmatch(text[matches[i-1][1]:matches[i][0]])
+		if joiner == nil {
+			break
+		}
+		next := durationAt(text, matches[i])
+		switch joiner[1] {
+		case "to", "-", "–":
+			total = max(total, next)
+		default:
+			total += next
+		}
+	}
+	return total
+}
+
+func durationAt(text string, match []int) time.Duration {
+	amount := parseAmount(text[match[2]:match[3]])
+	if match[4] >= 0 {
+		amount = parseAmount(text[match[4]:match[5]])
+	}
+
+	unit := strings.ToLower(text[match[6]:match[7]])
+	switch {
+	case strings.HasPrefix(unit, "h"):
+		return time.Duration(amount * float64(time.Hour))
+	case strings.HasPrefix(unit, "m"):
+		return time.Duration(amount * float64(time.Minute))
+	default:
+		return time.Duration(amount * float64(time.Second))
+	}
+}
+
+func parseAmount(value string) float64 {
+	value = strings.TrimSpace(value)
+	half := strings.HasSuffix(value, "1/2") || strings.HasSuffix(value, "½")
+	value = strings.TrimSpace(strings.TrimSuffix(strings.TrimSuffix(value, "1/2"), "½"))
+
+	amount, _ := strconv.ParseFloat(value, 64)
+	if half {
+		amount += 0.5
+	}
+	return amount
+}
+
+func splitSectionHeader(paragraph string) (string, string, bool) {
+	header, rest, found := strings.Cut(paragraph, ":")
+	if !found {
+		return "", "", false
+	}
+
+	header = strings.TrimSpace(markdownEmphasis.ReplaceAllString(header, ""))
+	if header == "" || len(header) > maxSectionLength || len(strings.Fields(header)) > maxSectionWords {
+		return "", "", false
+	}
+	for _, r := range header {
+		if unicode.IsDigit(r) {
+			return "", "", false
+		}
+	}
+	if asides[strings.ToLower(header)] {
+		return "", "", false
+	}
+	// The colon may sit inside the emphasis, as in "**To serve:** Slice thinly".
+	rest = strings.TrimSpace(rest)
+	if loc := markdownEmphasis.FindStringIndex(rest); loc != nil && loc[0] == 0 {
+		rest = strings.TrimSpace(rest[loc[1]:])
+	}
+	if hasLetters(rest) && !headingRegex.MatchString(header) {
+		return "", "", false
+	}
+	return header, rest, true
+}
+
+func hasLetters(text string) bool {
+	return strings.IndexFunc(text, unicode.IsLetter) >= 0
+}
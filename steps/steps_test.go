@@ -0,0 +1,113 @@
+package steps
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/mahesh-yadav/go-recipes-api/models"
+)
+
+func TestDetectTimer(t *testing.T) {
+	tests := []struct {
+		text string
+		want time.Duration
+	}{
+		{text: "Bake for 25 minutes.", want: 25 * time.Minute},
+		{text: "Simmer for about 4 to 5 minutes.", want: 5 * time.Minute},
+		{text: "Roast 8-10 mins, turning once.", want: 10 * time.Minute},
+		{text: "Braise for 1 1/2 hours.", want: 90 * time.Minute},
+		{text: "Rest for 1 hour, 30 minutes.", want: 90 * time.Minute},
+		{text: "Proof for 45 minutes to 1 hour.", want: time.Hour},
+		{text: "Whisk for 30 secs.", want: 30 * time.Second},
+		{text: "Bake 20 minutes, then cool for 10 minutes.", want: 20 * time.Minute},
+		{text: "Season to taste.", want: 0},
+	}
+	for _, test := range tests {
+		if got := DetectTimer(test.text); got != test.want {
+			t.Errorf("DetectTimer(%q) = %v, want %v", test.text, got, test.want)
+		}
+	}
+}
+
+func TestStructure(t *testing.T) {
+	tests := []struct {
+		name         string
+		instructions models.Instructions
+		want         models.Instructions
+	}{
+		{
+			name:         "paragraphs",
+			instructions: models.Instructions{{Text: "Chop the onion.\n\nFry it for 5 minutes.", ImageID: "img1"}},
+			want: models.Instructions{
+				{Text: "Chop the onion.", ImageID: "img1"},
+				{Text: "Fry it for 5 minutes.", TimerSeconds: 300},
+			},
+		},
+		{
+			name: "section lead-in",
+			instructions: models.Instructions{
+				{Text: "**For the sauce:** Melt the butter."},
+				{Text: "Whisk in the flour."},
+				{Section: "Assembly", Text: "Pour over the pasta."},
+			},
+			want: models.Instructions{
+				{Section: "For the sauce", Text: "Melt the butter."},
+				{Section: "For the sauce", Text: "Whisk in the flour."},
+				{Section: "Assembly", Text: "Pour over the pasta."},
+			},
+		},
+		{
+			name: "colons that are not sections",
+			instructions: models.Instructions{
+				{Text: "Step 2: Stir well."},
+				{Text: "When the sauce has thickened and coats a spoon: stir in the cream."},
+			},
+			want: models.Instructions{
+				{Text: "Step 2: Stir well."},
+				{Text: "When the sauce has thickened and coats a spoon: stir in the cream."},
+			},
+		},
+		{
+			name: "heading paragraph",
+			instructions: models.Instructions{
+				{Text: "To cook the chicken:\r\n\r\nSear it for 5 minutes.", TimerSeconds: 600, ImageID: "img1", Ingredients: []int{0}},
+				{Text: "Sauce:\n\nWhisk the stock and cream."},
+			},
+			want: models.Instructions{
+				{Section: "To cook the chicken", Text: "Sear it for 5 minutes.", TimerSeconds: 600, ImageID: "img1", Ingredients: []int{0}},
+				{Section: "Sauce", Text: "Whisk the stock and cream."},
+			},
+		},
+		{
+			name: "asides",
+			instructions: models.Instructions{
+				{Text: "Note: do not overmix."},
+				{Text: "Bake for 20 minutes."},
+				{Text: "Stir well: the sauce thickens as it cools."},
+			},
+			want: models.Instructions{
+				{Text: "Note: do not overmix."},
+				{Text: "Bake for 20 minutes.", TimerSeconds: 1200},
+				{Text: "Stir well: the sauce thickens as it cools."},
+			},
+		},
+		{
+			name:         "existing timer",
+			instructions: models.Instructions{{Text: "Bake for 25 minutes.", TimerSeconds: 60}},
+			want:         models.Instructions{{Text: "Bake for 25 minutes.", TimerSeconds: 60}},
+		},
+		{
+			name:         "no letters",
+			instructions: models.Instructions{{Text: "Cook.\n\n---"}},
+			want:         models.Instructions{{Text: "Cook."}},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := Structure(test.instructions); !reflect.DeepEqual(got, test.want) {
+				t.Errorf("Structure() = %+v\nwant %+v", got, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// LocalMediaPath is where the API serves files from a local blob store by default.
+const LocalMediaPath = "/media"
+
+type LocalBlobStore struct {
+	dir     string
+	baseURL string
+}
+
+func NewLocalBlobStore(dir string, baseURL string) *LocalBlobStore {
+	if baseURL == "" {
+		baseURL = LocalMediaPath
+	}
+	return &LocalBlobStore{
+		dir:     dir,
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+	}
+}
+
+func (store *LocalBlobStore) Put(ctx context.Context, key string, data []byte, contentType string) error {
+	path, err := store.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+func (store *LocalBlobStore) Get(ctx context.Context, key string) ([]byte, error) {
+	path, err := store.path(key)
+	if err != nil {
+		return nil, err
+	}
+	return os.ReadFile(path)
+}
+
+func (store *LocalBlobStore) Delete(ctx context.Context, key string) error {
+	path, err := store.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	// Remove directories left empty, up to the store root.
+	root := filepath.Clean(store.dir)
+	for dir := filepath.Dir(path); dir != root && strings.HasPrefix(dir, root); dir = filepath.Dir(dir) {
+		if os.Remove(dir) != nil {
+			break
+		}
+	}
+	return nil
+}
+
+func (store *LocalBlobStore) URL(key string) string {
+	return store.baseURL + "/" + key
+}
+
+func (store *LocalBlobStore) path(key string) (string, error) {
+	path := filepath.Join(store.dir, filepath.FromSlash(key))
+	if !strings.HasPrefix(path, filepath.Clean(store.dir)+string(filepath.Separator)) {
+		return "", errors.New("invalid blob key: " + key)
+	}
+	return path, nil
+}
@@ -0,0 +1,161 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+type S3Options struct {
+	Endpoint  string
+	Region    string
+	Bucket    string
+	AccessKey string
+	SecretKey string
+	// BaseURL is the public prefix for object URLs. It defaults to the bucket URL.
+	BaseURL string
+}
+
+// S3BlobStore talks to any S3 compatible service, such as AWS S3 or MinIO, using path
+// style requests signed with AWS Signature Version 4.
+type S3BlobStore struct {
+	options S3Options
+	client  *http.Client
+}
+
+func NewS3BlobStore(options S3Options) *S3BlobStore {
+	options.Endpoint = strings.TrimSuffix(options.Endpoint, "/")
+	if options.BaseURL == "" {
+		options.BaseURL = options.Endpoint + "/" + options.Bucket
+	}
+	options.BaseURL = strings.TrimSuffix(options.BaseURL, "/")
+
+	return &S3BlobStore{
+		options: options,
+		client:  &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+func (store *S3BlobStore) Put(ctx context.Context, key string, data []byte, contentType string) error {
+	_, err := store.do(ctx, http.MethodPut, key, data, contentType)
+	return err
+}
+
+func (store *S3BlobStore) Get(ctx context.Context, key string) ([]byte, error) {
+	return store.do(ctx, http.MethodGet, key, nil, "")
+}
+
+func (store *S3BlobStore) Delete(ctx context.Context, key string) error {
+	_, err := store.do(ctx, http.MethodDelete, key, nil, "")
+	return err
+}
+
+func (store *S3BlobStore) URL(key string) string {
+	return store.options.BaseURL + "/" + escapePath(key)
+}
+
+// do sends a signed request for an object and returns the response body.
+func (store *S3BlobStore) do(ctx context.Context, method string, key string, body []byte, contentType string) ([]byte, error) {
+	path := "/" + store.options.Bucket + "/" + escapePath(key)
+	request, err := http.NewRequestWithContext(ctx, method, store.options.Endpoint+path, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	if contentType != "" {
+		request.Header.Set("Content-Type", contentType)
+	}
+	store.sign(request, path, body, time.Now().UTC())
+
+	response, err := store.client.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode/100 != 2 && !(method == http.MethodDelete && response.StatusCode == http.StatusNotFound) {
+		message, _ := io.ReadAll(io.LimitReader(response.Body, 1024))
+		return nil, fmt.Errorf("s3 %s %s failed with status %d: %s", method, key, response.StatusCode, message)
+	}
+	return io.ReadAll(response.Body)
+}
+
+func (store *S3BlobStore) sign(request *http.Request, path string, body []byte, now time.Time) {
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+	payloadHash := sha256Hex(body)
+
+	request.Header.Set("X-Amz-Date", amzDate)
+	request.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	headers := map[string]string{"host": request.URL.Host}
+	for name := range request.Header {
+		headers[strings.ToLower(name)] = strings.TrimSpace(request.Header.Get(name))
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		request.Method,
+		path,
+		"",
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := date + "/" + store.options.Region + "/s3/aws4_request"
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		amzDate,
+		scope,
+		sha256Hex([]byte(canonicalRequest)),
+	}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+store.options.SecretKey), date)
+	key = hmacSHA256(key, store.options.Region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	request.Header.Set("Authorization", fmt.Sprintf(
+		"AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		store.options.AccessKey, scope, signedHeaders, signature,
+	))
+}
+
+func escapePath(key string) string {
+	segments := strings.Split(key, "/")
+	for i, segment := range segments {
+		segments[i] = strings.ReplaceAll(url.PathEscape(segment), "+", "%2B")
+	}
+	return strings.Join(segments, "/")
+}
+
+func sha256Hex(data []byte) string {
+	hash := sha256.Sum256(data)
+	return hex.EncodeToString(hash[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
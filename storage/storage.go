@@ -0,0 +1,34 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mahesh-yadav/go-recipes-api/config"
+)
+
+// BlobStore stores binary objects such as recipe images under slash separated keys.
+type BlobStore interface {
+	Put(ctx context.Context, key string, data []byte, contentType string) error
+	Get(ctx context.Context, key string) ([]byte, error)
+	Delete(ctx context.Context, key string) error
+	URL(key string) string
+}
+
+func NewBlobStore(config *config.Config) (BlobStore, error) {
+	switch config.BlobStore {
+	case "local":
+		return NewLocalBlobStore(config.BlobLocalDir, config.BlobBaseURL), nil
+	case "s3":
+		return NewS3BlobStore(S3Options{
+			Endpoint:  config.S3Endpoint,
+			Region:    config.S3Region,
+			Bucket:    config.S3Bucket,
+			AccessKey: config.S3AccessKey,
+			SecretKey: config.S3SecretKey,
+			BaseURL:   config.BlobBaseURL,
+		}), nil
+	default:
+		return nil, fmt.Errorf("unknown blob store: %s", config.BlobStore)
+	}
+}
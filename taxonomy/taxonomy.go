@@ -0,0 +1,113 @@
+// Package taxonomy resolves free-form tags against the managed tag hierarchy.
+package taxonomy
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/mahesh-yadav/go-recipes-api/models"
+)
+
+// Slugify lowercases a tag and joins its words with hyphens.
+func Slugify(tag string) string {
+	var slug strings.Builder
+	hyphen := false
+	for _, r := range strings.ToLower(strings.TrimSpace(tag)) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			if hyphen && slug.Len() > 0 {
+				slug.WriteByte('-')
+			}
+			slug.WriteRune(r)
+			hyphen = false
+		} else {
+			hyphen = true
+		}
+	}
+	return slug.String()
+}
+
+type Taxonomy struct {
+	tags      map[string]models.Tag
+	canonical map[string]string
+	children  map[string][]string
+}
+
+func New(tags []models.Tag) *Taxonomy {
+	taxonomy := &Taxonomy{
+		tags:      make(map[string]models.Tag, len(tags)),
+		canonical: make(map[string]string),
+		children:  make(map[string][]string),
+	}
+	for _, tag := range tags {
+		taxonomy.tags[tag.Slug] = tag
+		for _, synonym := range tag.Synonyms {
+			taxonomy.canonical[Slugify(synonym)] = tag.Slug
+		}
+		if tag.Parent != "" {
+			taxonomy.children[tag.Parent] = append(taxonomy.children[tag.Parent], tag.Slug)
+		}
+	}
+	// Slugs win over synonyms of other tags.
+	for slug := range taxonomy.tags {
+		taxonomy.canonical[slug] = slug
+	}
+	return taxonomy
+}
+
+// Get returns the managed tag with the given slug.
+func (taxonomy *Taxonomy) Get(slug string) (models.Tag, bool) {
+	tag, ok := taxonomy.tags[slug]
+	return tag, ok
+}
+
+// Canonical returns the slug a tag resolves to. Unmanaged tags resolve to their own slug.
+func (taxonomy *Taxonomy) Canonical(tag string) string {
+	slug := Slugify(tag)
+	if canonical, ok := taxonomy.canonical[slug]; ok {
+		return canonical
+	}
+	return slug
+}
+
+// CanonicalAll resolves each tag, dropping empty and duplicate results.
+func (taxonomy *Taxonomy) CanonicalAll(tags []string) []string {
+	seen := make(map[string]bool, len(tags))
+	canonical := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		slug := taxonomy.Canonical(tag)
+		if slug != "" && !seen[slug] {
+			seen[slug] = true
+			canonical = append(canonical, slug)
+		}
+	}
+	return canonical
+}
+
+// Expand resolves a tag and returns it with all of its descendants.
+func (taxonomy *Taxonomy) Expand(tag string) []string {
+	root := taxonomy.Canonical(tag)
+	expanded := []string{root}
+	seen := map[string]bool{root: true}
+	for i := 0; i < len(expanded); i++ {
+		for _, child := range taxonomy.children[expanded[i]] {
+			if !seen[child] {
+				seen[child] = true
+				expanded = append(expanded, child)
+			}
+		}
+	}
+	return expanded
+}
+
+// IsAncestor reports whether ancestor is slug itself or one of its parents.
+func (taxonomy *Taxonomy) IsAncestor(ancestor, slug string) bool {
+	seen := make(map[string]bool)
+	for slug != "" && !seen[slug] {
+		if slug == ancestor {
+			return true
+		}
+		seen[slug] = true
+		slug = taxonomy.tags[slug].Parent
+	}
+	return false
+}
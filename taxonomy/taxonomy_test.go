@@ -0,0 +1,105 @@
+package taxonomy
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/mahesh-yadav/go-recipes-api/models"
+)
+
+func newTestTaxonomy() *Taxonomy {
+	return New([]models.Tag{
+		{Slug: "poultry", Name: "Poultry", Synonyms: []string{"Fowl"}},
+		{Slug: "chicken", Name: "Chicken", Synonyms: []string{"Hen", "Poultry"}, Parent: "poultry"},
+		{Slug: "chicken-thighs", Name: "Chicken thighs", Parent: "chicken"},
+		{Slug: "duck", Name: "Duck", Parent: "poultry"},
+		// A cycle that the tag handlers should prevent but older data may contain.
+		{Slug: "sweet", Name: "Sweet", Parent: "dessert"},
+		{Slug: "dessert", Name: "Dessert", Synonyms: []string{"Pudding"}, Parent: "sweet"},
+	})
+}
+
+func TestSlugify(t *testing.T) {
+	tests := map[string]string{
+		"Chicken":            "chicken",
+		"  Gluten Free  ":    "gluten-free",
+		"gluten--free!":      "gluten-free",
+		"Crème brûlée":       "crème-brûlée",
+		"-quick & easy (30)": "quick-easy-30",
+		"":                   "",
+	}
+	for tag, want := range tests {
+		if got := Slugify(tag); got != want {
+			t.Errorf("Slugify(%q) = %q, want %q", tag, got, want)
+		}
+	}
+}
+
+func TestCanonical(t *testing.T) {
+	taxonomy := newTestTaxonomy()
+	tests := map[string]string{
+		"Chicken":   "chicken",
+		"hen":       "chicken",
+		"FOWL":      "poultry",
+		"poultry":   "poultry",
+		"pudding":   "dessert",
+		"Tofu Bowl": "tofu-bowl",
+	}
+	for tag, want := range tests {
+		if got := taxonomy.Canonical(tag); got != want {
+			t.Errorf("Canonical(%q) = %q, want %q", tag, got, want)
+		}
+	}
+}
+
+func TestCanonicalAll(t *testing.T) {
+	got := newTestTaxonomy().CanonicalAll([]string{"Hen", "chicken", " ", "fowl", "tofu"})
+	if want := []string{"chicken", "poultry", "tofu"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("CanonicalAll() = %q, want %q", got, want)
+	}
+}
+
+func TestExpand(t *testing.T) {
+	taxonomy := newTestTaxonomy()
+	tests := []struct {
+		tag  string
+		want []string
+	}{
+		{tag: "fowl", want: []string{"poultry", "chicken", "duck", "chicken-thighs"}},
+		{tag: "hen", want: []string{"chicken", "chicken-thighs"}},
+		{tag: "duck", want: []string{"duck"}},
+		{tag: "tofu", want: []string{"tofu"}},
+		{tag: "pudding", want: []string{"dessert", "sweet"}},
+		{tag: "sweet", want: []string{"sweet", "dessert"}},
+	}
+	for _, test := range tests {
+		if got := taxonomy.Expand(test.tag); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("Expand(%q) = %q, want %q", test.tag, got, test.want)
+		}
+	}
+}
+
+func TestIsAncestor(t *testing.T) {
+	taxonomy := newTestTaxonomy()
+	tests := []struct {
+		ancestor string
+		slug     string
+		want     bool
+	}{
+		{ancestor: "chicken", slug: "chicken", want: true},
+		{ancestor: "chicken", slug: "chicken-thighs", want: true},
+		{ancestor: "poultry", slug: "chicken-thighs", want: true},
+		{ancestor: "chicken-thighs", slug: "poultry", want: false},
+		{ancestor: "duck", slug: "chicken", want: false},
+		{ancestor: "tofu", slug: "tofu", want: true},
+		{ancestor: "poultry", slug: "", want: false},
+		{ancestor: "dessert", slug: "sweet", want: true},
+		{ancestor: "sweet", slug: "dessert", want: true},
+		{ancestor: "poultry", slug: "dessert", want: false},
+	}
+	for _, test := range tests {
+		if got := taxonomy.IsAncestor(test.ancestor, test.slug); got != test.want {
+			t.Errorf("IsAncestor(%q, %q) = %v, want %v", test.ancestor, test.slug, got, test.want)
+		}
+	}
+}